@@ -0,0 +1,6 @@
+# List every action configuration on the tenant.
+data "authsignal_action_configurations" "all" {}
+
+output "action_codes" {
+  value = data.authsignal_action_configurations.all.action_configurations[*].action_code
+}
@@ -0,0 +1,6 @@
+# List every custom data point on the tenant.
+data "authsignal_custom_data_points" "all" {}
+
+output "custom_data_point_ids" {
+  value = { for point in data.authsignal_custom_data_points.all.custom_data_points : point.name => point.id }
+}
@@ -0,0 +1,33 @@
+# Evaluate a login policy against a sample payload without calling the Authsignal API.
+data "authsignal_rule_evaluation" "anonymous_login" {
+  payload = jsonencode({
+    ip   = { isAnonymous = true, countryCode = "NZ" }
+    user = { email = "jane@example.com" }
+  })
+  value_lists = {
+    "blocked-emails" = ["bad@example.com"]
+  }
+  default_type = "ALLOW"
+  rules = [
+    {
+      rule_id    = authsignal_rule.block_emails.rule_id
+      priority   = authsignal_rule.block_emails.priority
+      type       = authsignal_rule.block_emails.type
+      conditions = authsignal_rule.block_emails.conditions
+    },
+    {
+      rule_id              = authsignal_rule.challenge_anonymous.rule_id
+      priority             = authsignal_rule.challenge_anonymous.priority
+      type                 = authsignal_rule.challenge_anonymous.type
+      verification_methods = authsignal_rule.challenge_anonymous.verification_methods
+      conditions           = authsignal_rule.challenge_anonymous.conditions
+    },
+  ]
+}
+
+check "anonymous_logins_are_challenged" {
+  assert {
+    condition     = data.authsignal_rule_evaluation.anonymous_login.type == "CHALLENGE"
+    error_message = "Logins from anonymous IPs should be challenged."
+  }
+}
@@ -0,0 +1,13 @@
+# List the active CHALLENGE rules on an action.
+data "authsignal_rules" "login_challenges" {
+  action_code = "login"
+  type        = "CHALLENGE"
+  is_active   = true
+}
+
+# List every rule on the tenant.
+data "authsignal_rules" "all" {}
+
+output "rule_names" {
+  value = [for rule in data.authsignal_rules.all.rules : "${rule.action_code}/${rule.name}"]
+}
@@ -0,0 +1,6 @@
+# List every value list on the tenant.
+data "authsignal_value_lists" "all" {}
+
+output "value_list_aliases" {
+  value = data.authsignal_value_lists.all.value_lists[*].alias
+}
@@ -0,0 +1,11 @@
+resource "authsignal_rule" "anonymous_blocked_email" {
+  action_code = "test-rules"
+  name        = "Anonymous IP with a blocked email"
+  type        = "BLOCK"
+  priority    = 1
+  is_active   = true
+  conditions = provider::authsignal::condition_all(
+    provider::authsignal::condition_eq("ip.isAnonymous", true),
+    provider::authsignal::condition_in_list("user.email", "blocked-emails"),
+  )
+}
@@ -0,0 +1,11 @@
+resource "authsignal_rule" "anonymous_or_blocked_email" {
+  action_code = "test-rules"
+  name        = "Anonymous IP or a blocked email"
+  type        = "CHALLENGE"
+  priority    = 2
+  is_active   = true
+  conditions = provider::authsignal::condition_any(
+    provider::authsignal::condition_eq("ip.isAnonymous", true),
+    provider::authsignal::condition_in_list("user.email", "blocked-emails"),
+  )
+}
@@ -0,0 +1,9 @@
+# {"==":[{"var":"ip.isAnonymous"},true]}
+output "anonymous_ip" {
+  value = provider::authsignal::condition_eq("ip.isAnonymous", true)
+}
+
+# {"==":[{"var":"ip.countryCode"},"NZ"]}
+output "new_zealand" {
+  value = provider::authsignal::condition_eq("ip.countryCode", "NZ")
+}
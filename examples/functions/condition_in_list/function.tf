@@ -0,0 +1,12 @@
+resource "authsignal_value_list" "blocked_emails" {
+  name      = "Blocked emails"
+  is_active = true
+  value_list_items_strings = [
+    "fraudster@example.com",
+  ]
+}
+
+# {"in":[{"var":"user.email"},{"var":"valueLists.blocked-emails"}]}
+output "blocked_email" {
+  value = provider::authsignal::condition_in_list("user.email", authsignal_value_list.blocked_emails.alias)
+}
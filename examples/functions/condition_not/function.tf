@@ -0,0 +1,6 @@
+# {"!":[{"in":[{"var":"user.email"},{"var":"valueLists.allowed-emails"}]}]}
+output "not_allowed_email" {
+  value = provider::authsignal::condition_not(
+    provider::authsignal::condition_in_list("user.email", "allowed-emails"),
+  )
+}
@@ -0,0 +1,8 @@
+resource "authsignal_rule" "from_file" {
+  action_code = "test-rules"
+  name        = "Rule written by hand"
+  type        = "REVIEW"
+  priority    = 3
+  is_active   = true
+  conditions  = provider::authsignal::normalize_conditions(file("${path.module}/conditions.json"))
+}
@@ -7,3 +7,71 @@ provider "authsignal" {
 }
 
 # These values can be found under the `Settings -> API keys` section of Authsignal's admin portal.
+
+# A tenant in one of Authsignal's regions can set the region instead of the host.
+provider "authsignal" {
+  alias = "eu"
+
+  region     = "eu" # AUTHSIGNAL_REGION
+  tenant_id  = "123"
+  api_secret = "helloworld"
+}
+
+# The API secret can be read from a file, or printed by a command such as a vault CLI, instead of
+# being in the configuration or the environment.
+provider "authsignal" {
+  alias = "vault"
+
+  region             = "us"
+  tenant_id          = "123"
+  api_secret_command = ["vault", "kv", "get", "-field=api_secret", "secret/authsignal"] # AUTHSIGNAL_API_SECRET_COMMAND
+}
+
+# Credentials from a profile of the credentials file, `~/.authsignal/credentials` by default, such as:
+#
+#   [staging]
+#   region          = us
+#   tenant_id       = 456
+#   api_secret_file = /run/secrets/authsignal-staging
+#
+# Each of host or region, tenant_id and the API secret is taken from the first of these that sets it:
+#   1. the provider configuration
+#   2. the AUTHSIGNAL_HOST, AUTHSIGNAL_REGION, AUTHSIGNAL_TENANT_ID and AUTHSIGNAL_API_SECRET environment variables,
+#      or AUTHSIGNAL_API_SECRET_FILE or AUTHSIGNAL_API_SECRET_COMMAND for the API secret
+#   3. the profile named by `profile` or AUTHSIGNAL_PROFILE, or else the `default` profile if the file has one
+provider "authsignal" {
+  alias = "profile"
+
+  profile = "staging" # AUTHSIGNAL_PROFILE
+}
+
+# Several tenants from one provider configuration. Resources and data sources select a tenant with
+# their `tenant` attribute, and otherwise use the provider's own tenant.
+provider "authsignal" {
+  alias = "all"
+
+  host       = "https://api.authsignal.com/v1/management"
+  tenant_id  = "123"
+  api_secret = "helloworld"
+
+  tenants = {
+    staging = {
+      tenant_id  = "456"
+      api_secret = "staging-secret"
+    }
+    au = {
+      region     = "au"
+      tenant_id  = "789"
+      api_secret = "au-secret"
+    }
+  }
+}
+
+resource "authsignal_value_list" "staging_blocked_emails" {
+  provider = authsignal.all
+  tenant   = "staging"
+
+  name                     = "Blocked emails"
+  is_active                = true
+  value_list_items_strings = ["blocked@example.com"]
+}
@@ -1,2 +1,5 @@
 # action configurations can be imported by specifying the action code.
-terraform import authsignal_action_configuration.test test-action-code
\ No newline at end of file
+terraform import authsignal_action_configuration.test test-action-code
+
+# A resource in one of the provider's other `tenants` is imported with the tenant's key before the ID.
+terraform import authsignal_action_configuration.test staging:test-action-code
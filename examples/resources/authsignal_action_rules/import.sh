@@ -0,0 +1,7 @@
+# all rules of an action can be imported by specifying the action code.
+# Importing lists the action's rules, which the Management API client cannot do yet, so until it
+# can, import each rule as an `authsignal_rule` instead.
+terraform import authsignal_action_rules.login "login"
+
+# A resource in one of the provider's other `tenants` is imported with the tenant's key before the ID.
+terraform import authsignal_action_rules.login "staging:login"
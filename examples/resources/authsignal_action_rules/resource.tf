@@ -0,0 +1,19 @@
+# Rules are applied in the order they are listed: the first rule gets priority 0, the second priority 1, and so on.
+resource "authsignal_action_rules" "login" {
+  action_code = "login"
+
+  rule {
+    name       = "Block anonymous IPs"
+    type       = "BLOCK"
+    is_active  = true
+    conditions = jsonencode({ "==" : [{ "var" : "ip.isAnonymous" }, true] })
+  }
+
+  rule {
+    name                 = "Challenge new devices"
+    type                 = "CHALLENGE"
+    is_active            = true
+    verification_methods = ["EMAIL_OTP", "PASSKEY"]
+    conditions           = jsonencode({ "==" : [{ "var" : "device.isKnown" }, false] })
+  }
+}
@@ -1,3 +1,5 @@
 # Custom data points can be imported using their ID
 terraform import authsignal_custom_data_point.my_custom_data_point abcd_efgh
 
+# A resource in one of the provider's other `tenants` is imported with the tenant's key before the ID.
+terraform import authsignal_custom_data_point.my_custom_data_point staging:abcd_efgh
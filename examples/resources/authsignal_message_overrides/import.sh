@@ -1,2 +1,5 @@
 # Message overrides are a tenant-wide singleton and can be imported with the following command. An empty string is needed for the command to run.
-terraform import authsignal_message_overrides.message_overrides ""
\ No newline at end of file
+terraform import authsignal_message_overrides.message_overrides ""
+
+# A resource in one of the provider's other `tenants` is imported with the tenant's key before the ID.
+terraform import authsignal_message_overrides.message_overrides "staging:"
@@ -1,2 +1,5 @@
 # A tenant has one set of pre-built UI settings, so there is no ID to import by. The empty string is required for the command to run.
 terraform import authsignal_pre_built_ui_settings.pre_built_ui_settings ""
+
+# A resource in one of the provider's other `tenants` is imported with the tenant's key before the ID.
+terraform import authsignal_pre_built_ui_settings.pre_built_ui_settings "staging:"
@@ -1,2 +1,5 @@
 # rules can be imported by specifying the action code.
-terraform import authsignal_rule.test "test-action-code/a2d9670f-4028-424c-9f0f-1493ed9efc45"
\ No newline at end of file
+terraform import authsignal_rule.test "test-action-code/a2d9670f-4028-424c-9f0f-1493ed9efc45"
+
+# A resource in one of the provider's other `tenants` is imported with the tenant's key before the ID.
+terraform import authsignal_rule.test "staging:test-action-code/a2d9670f-4028-424c-9f0f-1493ed9efc45"
@@ -28,3 +28,35 @@ resource "authsignal_rule" "test" {
     ]
   })
 }
+
+# create a rule using typed condition blocks instead of jsonencode
+resource "authsignal_rule" "typed" {
+  action_code = "test-rules"
+  name        = "block-anonymous-risky-ips"
+  priority    = 3
+  type        = "BLOCK"
+  is_active   = true
+
+  condition {
+    all {
+      match {
+        var        = "ip.isAnonymous"
+        op         = "=="
+        bool_value = true
+      }
+
+      any {
+        match {
+          var           = "ip.countryCode"
+          op            = "in"
+          string_values = ["KP", "IR"]
+        }
+        match {
+          var        = "user.email"
+          op         = "in"
+          value_list = "blocked-emails"
+        }
+      }
+    }
+  }
+}
@@ -1,2 +1,5 @@
 # themes can be imported with the following command. An empty string is needed for the command to run.
-terraform import authsignal_theme.theme ""
\ No newline at end of file
+terraform import authsignal_theme.theme ""
+
+# A resource in one of the provider's other `tenants` is imported with the tenant's key before the ID.
+terraform import authsignal_theme.theme "staging:"
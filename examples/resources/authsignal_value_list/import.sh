@@ -1,2 +1,5 @@
 # value lists can be imported with the following command. The value provided is the value list alias.
-terraform import authsignal_value_list.example_value_list "example-value-list"
\ No newline at end of file
+terraform import authsignal_value_list.example_value_list "example-value-list"
+
+# A resource in one of the provider's other `tenants` is imported with the tenant's key before the ID.
+terraform import authsignal_value_list.example_value_list "staging:example-value-list"
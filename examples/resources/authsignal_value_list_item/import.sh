@@ -0,0 +1,5 @@
+# value list items can be imported with the following command. The value provided is the value list alias and the item, separated by a slash.
+terraform import authsignal_value_list_item.example_value_list_item "example-value-list/hello"
+
+# A resource in one of the provider's other `tenants` is imported with the tenant's key before the ID.
+terraform import authsignal_value_list_item.example_value_list_item "staging:example-value-list/hello"
@@ -0,0 +1,13 @@
+resource "authsignal_value_list" "blocked" {
+  name                     = "Blocked"
+  is_active                = true
+  items_managed_externally = true
+  value_list_items_strings = [
+    "fraud@example.com",
+  ]
+}
+
+resource "authsignal_value_list_item" "blocked_ip_range" {
+  alias = authsignal_value_list.blocked.alias
+  value = "10.0.0.0/8"
+}
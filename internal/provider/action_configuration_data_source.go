@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/authsignal/authsignal-management-go/v6"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -22,7 +21,7 @@ func NewActionConfigurationDataSource() datasource.DataSource {
 }
 
 type actionConfigurationDataSource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 type actionConfigurationDataSourceModel struct {
@@ -34,6 +33,7 @@ type actionConfigurationDataSourceModel struct {
 	VerificationMethods               types.List   `tfsdk:"verification_methods"`
 	PromptToEnrollVerificationMethods types.List   `tfsdk:"prompt_to_enroll_verification_methods"`
 	DefaultVerificationMethod         types.String `tfsdk:"default_verification_method"`
+	Tenant                            types.String `tfsdk:"tenant"`
 }
 
 func (d *actionConfigurationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -43,6 +43,7 @@ func (d *actionConfigurationDataSource) Metadata(_ context.Context, req datasour
 func (d *actionConfigurationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantDataSourceAttribute(),
 			"action_code": schema.StringAttribute{
 				Description: "The name of the action that users perform which you will track. (e.g 'login')",
 				Required:    true,
@@ -90,7 +91,13 @@ func (d *actionConfigurationDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 
-	actionConfiguration, _, err := d.client.GetActionConfiguration(data.ActionCode.ValueString())
+	client, diags := d.client.forTenant(data.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actionConfiguration, _, err := client.GetActionConfiguration(ctx, data.ActionCode.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -122,6 +129,7 @@ func (d *actionConfigurationDataSource) Read(ctx context.Context, req datasource
 	}
 
 	actionConfigurationState := actionConfigurationDataSourceModel{
+		Tenant:                            data.Tenant,
 		ActionCode:                        types.StringValue(actionConfiguration.ActionCode),
 		TenantId:                          types.StringValue(actionConfiguration.TenantId),
 		DefaultUserActionResult:           types.StringValue(actionConfiguration.DefaultUserActionResult),
@@ -157,12 +165,12 @@ func (d *actionConfigurationDataSource) Configure(ctx context.Context, req datas
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// `localized_messaging_templates` is a typed alternative to the deprecated `messaging_templates` JSON
+// string. Whichever one is configured, the other is computed from it at plan time, so both always
+// describe the templates that will be sent and either can be referenced elsewhere.
+
+var messagingTemplateLocalePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+var messagingTemplateAttributeTypes = map[string]attr.Type{
+	"default_template": types.StringType,
+}
+
+type messagingTemplateModel struct {
+	DefaultTemplate types.String `tfsdk:"default_template"`
+}
+
+func localizedMessagingTemplatesAttribute() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Description: "Optional messaging templates to be shown in Authsignal's pre-built UI, keyed by locale such as `en` or `pt-BR`. Conflicts with `messaging_templates`, which is computed from this.",
+		Optional:    true,
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"default_template": schema.StringAttribute{
+					Description: "The template shown for the locale.",
+					Required:    true,
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					},
+				},
+			},
+		},
+		Validators: []validator.Map{
+			mapvalidator.KeysAre(stringvalidator.RegexMatches(messagingTemplateLocalePattern, "must be a locale such as `en` or `pt-BR`")),
+		},
+		PlanModifiers: []planmodifier.Map{
+			localizedMessagingTemplatesFromJson{},
+		},
+	}
+}
+
+// messagingTemplatesJsonFromMap encodes the typed templates the way the API takes them. It returns an
+// empty string when the templates are not known yet.
+func messagingTemplatesJsonFromMap(ctx context.Context, templates types.Map) (string, diag.Diagnostics) {
+	if templates.IsNull() || templates.IsUnknown() {
+		return "", nil
+	}
+
+	var locales map[string]messagingTemplateModel
+	diags := templates.ElementsAs(ctx, &locales, false)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	messagingTemplates := map[string]any{}
+	for locale, template := range locales {
+		if template.DefaultTemplate.IsUnknown() {
+			return "", diags
+		}
+		messagingTemplates[locale] = map[string]any{"defaultTemplate": template.DefaultTemplate.ValueString()}
+	}
+
+	messagingTemplatesJson, err := json.Marshal(messagingTemplates)
+	if err != nil {
+		diags.AddError("Unable to marshal messaging templates", err.Error())
+		return "", diags
+	}
+
+	return string(messagingTemplatesJson), diags
+}
+
+// messagingTemplatesMapFromJson decodes messaging templates JSON into the typed form. A locale without
+// a string `defaultTemplate` gets a null `default_template`.
+func messagingTemplatesMapFromJson(messagingTemplatesJson string) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	elementType := types.ObjectType{AttrTypes: messagingTemplateAttributeTypes}
+
+	var locales map[string]any
+	if err := json.Unmarshal([]byte(messagingTemplatesJson), &locales); err != nil {
+		diags.AddError("Unable to unmarshal messaging templates", err.Error())
+		return types.MapNull(elementType), diags
+	}
+
+	if locales == nil {
+		return types.MapNull(elementType), diags
+	}
+
+	elements := map[string]attr.Value{}
+	for locale, template := range locales {
+		defaultTemplate := types.StringNull()
+		if fields, ok := template.(map[string]any); ok {
+			if value, ok := fields["defaultTemplate"].(string); ok {
+				defaultTemplate = types.StringValue(value)
+			}
+		}
+
+		element, elementDiags := types.ObjectValue(messagingTemplateAttributeTypes, map[string]attr.Value{
+			"default_template": defaultTemplate,
+		})
+		diags.Append(elementDiags...)
+		elements[locale] = element
+	}
+
+	templates, mapDiags := types.MapValue(elementType, elements)
+	diags.Append(mapDiags...)
+
+	return templates, diags
+}
+
+// messagingTemplatesJsonFromTyped computes `messaging_templates` from `localized_messaging_templates`
+// when the JSON string is not configured, and plans it to be removed when neither is.
+type messagingTemplatesJsonFromTyped struct{}
+
+func (m messagingTemplatesJsonFromTyped) Description(_ context.Context) string {
+	return "Encodes localized_messaging_templates into messaging_templates when messaging_templates is not set directly."
+}
+
+func (m messagingTemplatesJsonFromTyped) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m messagingTemplatesJsonFromTyped) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var templates types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("localized_messaging_templates"), &templates)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if templates.IsNull() {
+		resp.PlanValue = types.StringNull()
+		return
+	}
+
+	messagingTemplates, diags := messagingTemplatesJsonFromMap(ctx, templates)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || messagingTemplates == "" {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+
+	// Keep the stored JSON when it is the same document, however the API happened to format it.
+	if !req.StateValue.IsNull() && jsonSemanticallyEqual(req.StateValue.ValueString(), messagingTemplates) {
+		resp.PlanValue = req.StateValue
+		return
+	}
+
+	resp.PlanValue = types.StringValue(messagingTemplates)
+}
+
+// localizedMessagingTemplatesFromJson computes `localized_messaging_templates` from the deprecated
+// `messaging_templates` when the typed form is not configured, and plans it to be removed when neither
+// is.
+type localizedMessagingTemplatesFromJson struct{}
+
+func (m localizedMessagingTemplatesFromJson) Description(_ context.Context) string {
+	return "Decodes messaging_templates into localized_messaging_templates when localized_messaging_templates is not set directly."
+}
+
+func (m localizedMessagingTemplatesFromJson) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m localizedMessagingTemplatesFromJson) PlanModifyMap(ctx context.Context, req planmodifier.MapRequest, resp *planmodifier.MapResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var messagingTemplates normalizedJsonValue
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("messaging_templates"), &messagingTemplates)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	elementType := types.ObjectType{AttrTypes: messagingTemplateAttributeTypes}
+
+	switch {
+	case messagingTemplates.IsNull():
+		resp.PlanValue = types.MapNull(elementType)
+	case messagingTemplates.IsUnknown():
+		resp.PlanValue = types.MapUnknown(elementType)
+	default:
+		templates, diags := messagingTemplatesMapFromJson(messagingTemplates.ValueString())
+		resp.Diagnostics.Append(diags...)
+		resp.PlanValue = templates
+	}
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMessagingTemplatesRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	templates, diags := messagingTemplatesMapFromJson(`{"fr": {"defaultTemplate": "bonjour"}, "en": {"defaultTemplate": "hello world"}}`)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	expected := types.MapValueMust(types.ObjectType{AttrTypes: messagingTemplateAttributeTypes}, map[string]attr.Value{
+		"en": types.ObjectValueMust(messagingTemplateAttributeTypes, map[string]attr.Value{"default_template": types.StringValue("hello world")}),
+		"fr": types.ObjectValueMust(messagingTemplateAttributeTypes, map[string]attr.Value{"default_template": types.StringValue("bonjour")}),
+	})
+	if !templates.Equal(expected) {
+		t.Fatalf("bad templates. expected: %s. got : %s", expected, templates)
+	}
+
+	messagingTemplates, diags := messagingTemplatesJsonFromMap(ctx, templates)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	expectedJson := `{"en":{"defaultTemplate":"hello world"},"fr":{"defaultTemplate":"bonjour"}}`
+	if messagingTemplates != expectedJson {
+		t.Fatalf("bad messaging templates. expected: %s. got : %s", expectedJson, messagingTemplates)
+	}
+}
+
+func TestMessagingTemplatesFromJsonWithoutDefaultTemplate(t *testing.T) {
+	templates, diags := messagingTemplatesMapFromJson(`{"en": {"other": 1}}`)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	en := templates.Elements()["en"].(types.Object)
+	if !en.Attributes()["default_template"].IsNull() {
+		t.Fatalf("expected a null default_template, got: %s", en)
+	}
+
+	templates, diags = messagingTemplatesMapFromJson(`null`)
+	if diags.HasError() || !templates.IsNull() {
+		t.Fatalf("expected null templates, got: %s %v", templates, diags)
+	}
+
+	if _, diags := messagingTemplatesMapFromJson(`["en"]`); !diags.HasError() {
+		t.Fatal("expected an error for templates that are not an object")
+	}
+}
+
+func TestMessagingTemplatesJsonFromUnknownMap(t *testing.T) {
+	ctx := context.Background()
+
+	templates := types.MapValueMust(types.ObjectType{AttrTypes: messagingTemplateAttributeTypes}, map[string]attr.Value{
+		"en": types.ObjectValueMust(messagingTemplateAttributeTypes, map[string]attr.Value{"default_template": types.StringUnknown()}),
+	})
+
+	messagingTemplates, diags := messagingTemplatesJsonFromMap(ctx, templates)
+	if diags.HasError() || messagingTemplates != "" {
+		t.Fatalf("expected no JSON while a template is unknown, got: %q %v", messagingTemplates, diags)
+	}
+}
+
+func TestActionConfigurationUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &actionConfigurationResource{}
+
+	upgrader := r.UpgradeState(ctx)[0]
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	for name, testCase := range map[string]struct {
+		messagingTemplates types.String
+		expectedJson       string
+		expectedLocales    map[string]string
+	}{
+		"with templates": {
+			messagingTemplates: types.StringValue("{\n  \"en\": {\"defaultTemplate\": \"hello world\"}\n}"),
+			expectedJson:       "{\n  \"en\": {\"defaultTemplate\": \"hello world\"}\n}",
+			expectedLocales:    map[string]string{"en": "hello world"},
+		},
+		"without templates": {
+			messagingTemplates: types.StringNull(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			priorState := tfsdk.State{Schema: *upgrader.PriorSchema}
+			diags := priorState.Set(ctx, actionConfigurationResourceModelV0{
+				ActionCode:                        types.StringValue("signIn"),
+				LastActionCreatedAt:               types.StringValue("2024-01-01T00:00:00.000Z"),
+				TenantId:                          types.StringValue("tenant"),
+				DefaultUserActionResult:           types.StringValue("ALLOW"),
+				MessagingTemplates:                testCase.messagingTemplates,
+				VerificationMethods:               types.ListNull(types.StringType),
+				PromptToEnrollVerificationMethods: types.ListNull(types.StringType),
+				DefaultVerificationMethod:         types.StringNull(),
+				Tenant:                            types.StringNull(),
+			})
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &priorState}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var upgraded actionConfigurationResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &upgraded)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if upgraded.ActionCode.ValueString() != "signIn" || upgraded.DefaultUserActionResult.ValueString() != "ALLOW" {
+				t.Fatalf("attributes were not carried over: %+v", upgraded)
+			}
+
+			if upgraded.MessagingTemplates.ValueString() != testCase.expectedJson {
+				t.Fatalf("bad messaging templates. expected: %q. got : %q", testCase.expectedJson, upgraded.MessagingTemplates.ValueString())
+			}
+
+			var locales map[string]messagingTemplateModel
+			resp.Diagnostics.Append(upgraded.LocalizedMessagingTemplates.ElementsAs(ctx, &locales, true)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if len(locales) != len(testCase.expectedLocales) {
+				t.Fatalf("bad localized messaging templates. expected: %v. got : %v", testCase.expectedLocales, locales)
+			}
+			for locale, defaultTemplate := range testCase.expectedLocales {
+				if locales[locale].DefaultTemplate.ValueString() != defaultTemplate {
+					t.Fatalf("bad default template for %s. expected: %q. got : %q", locale, defaultTemplate, locales[locale].DefaultTemplate.ValueString())
+				}
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/authsignal/authsignal-management-go/v6"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,9 +19,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &actionConfigurationResource{}
-	_ resource.ResourceWithConfigure   = &actionConfigurationResource{}
-	_ resource.ResourceWithImportState = &actionConfigurationResource{}
+	_ resource.Resource                     = &actionConfigurationResource{}
+	_ resource.ResourceWithConfigure        = &actionConfigurationResource{}
+	_ resource.ResourceWithImportState      = &actionConfigurationResource{}
+	_ resource.ResourceWithConfigValidators = &actionConfigurationResource{}
+	_ resource.ResourceWithUpgradeState     = &actionConfigurationResource{}
 )
 
 func NewActionConfigurationResource() resource.Resource {
@@ -28,10 +31,25 @@ func NewActionConfigurationResource() resource.Resource {
 }
 
 type actionConfigurationResource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 type actionConfigurationResourceModel struct {
+	ActionCode                        types.String        `tfsdk:"action_code"`
+	LastActionCreatedAt               types.String        `tfsdk:"last_action_created_at"`
+	TenantId                          types.String        `tfsdk:"tenant_id"`
+	DefaultUserActionResult           types.String        `tfsdk:"default_user_action_result"`
+	MessagingTemplates                normalizedJsonValue `tfsdk:"messaging_templates"`
+	LocalizedMessagingTemplates       types.Map           `tfsdk:"localized_messaging_templates"`
+	VerificationMethods               types.List          `tfsdk:"verification_methods"`
+	PromptToEnrollVerificationMethods types.List          `tfsdk:"prompt_to_enroll_verification_methods"`
+	DefaultVerificationMethod         types.String        `tfsdk:"default_verification_method"`
+	Tenant                            types.String        `tfsdk:"tenant"`
+}
+
+// actionConfigurationResourceModelV0 is the state before `localized_messaging_templates`, when
+// `messaging_templates` held the JSON exactly as configured.
+type actionConfigurationResourceModelV0 struct {
 	ActionCode                        types.String `tfsdk:"action_code"`
 	LastActionCreatedAt               types.String `tfsdk:"last_action_created_at"`
 	TenantId                          types.String `tfsdk:"tenant_id"`
@@ -40,6 +58,7 @@ type actionConfigurationResourceModel struct {
 	VerificationMethods               types.List   `tfsdk:"verification_methods"`
 	PromptToEnrollVerificationMethods types.List   `tfsdk:"prompt_to_enroll_verification_methods"`
 	DefaultVerificationMethod         types.String `tfsdk:"default_verification_method"`
+	Tenant                            types.String `tfsdk:"tenant"`
 }
 
 func (r *actionConfigurationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -48,7 +67,9 @@ func (r *actionConfigurationResource) Metadata(_ context.Context, req resource.M
 
 func (r *actionConfigurationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantResourceAttribute(),
 			"action_code": schema.StringAttribute{
 				Description: "The name of the action that users perform which you will track. (e.g 'login')",
 				Required:    true,
@@ -75,9 +96,16 @@ func (r *actionConfigurationResource) Schema(_ context.Context, _ resource.Schem
 				},
 			},
 			"messaging_templates": schema.StringAttribute{
-				Description: "Optional messaging templates to be shown in Authsignal's pre-built UI.",
-				Optional:    true,
+				Description:        "Optional messaging templates to be shown in Authsignal's pre-built UI, as a JSON string. Conflicts with `localized_messaging_templates`, and is computed from it when that is used.",
+				DeprecationMessage: "Use localized_messaging_templates instead.",
+				CustomType:         normalizedJsonType{},
+				Optional:           true,
+				Computed:           true,
+				PlanModifiers: []planmodifier.String{
+					messagingTemplatesJsonFromTyped{},
+				},
 			},
+			"localized_messaging_templates": localizedMessagingTemplatesAttribute(),
 			"verification_methods": schema.ListAttribute{
 				ElementType: types.StringType,
 				Description: "A list of permitted authenticators that can be used if the result of the action is 'CHALLENGE'. Allowed values: `SMS`, `AUTHENTICATOR_APP`, `EMAIL_MAGIC_LINK`, `EMAIL_OTP`, `DEVICE`, `PUSH`, `QR_CODE`, `IN_APP`, `SECURITY_KEY`, `PASSKEY`, `VERIFF`, `IPROOV`, `PALM_BIOMETRICS_RR`, `IDVERSE`, `ONFIDO`, `APPLE_ID_TOKEN`, `GOOGLE_ID_TOKEN`, `WHATSAPP`, `DIGITAL_CREDENTIAL`, `OIDC_PROVIDER`.",
@@ -105,7 +133,69 @@ func (r *actionConfigurationResource) Schema(_ context.Context, _ resource.Schem
 	}
 }
 
+func (r *actionConfigurationResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("messaging_templates"),
+			path.MatchRoot("localized_messaging_templates"),
+		),
+	}
+}
+
+func (r *actionConfigurationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var priorSchema resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &priorSchema)
+	delete(priorSchema.Schema.Attributes, "localized_messaging_templates")
+	priorSchema.Schema.Attributes["messaging_templates"] = schema.StringAttribute{Optional: true}
+	priorSchema.Schema.Version = 0
+
+	return map[int64]resource.StateUpgrader{
+		// Version 0 kept messaging_templates as configured. The typed form is decoded from it.
+		0: {
+			PriorSchema: &priorSchema.Schema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior actionConfigurationResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := actionConfigurationResourceModel{
+					ActionCode:                        prior.ActionCode,
+					LastActionCreatedAt:               prior.LastActionCreatedAt,
+					TenantId:                          prior.TenantId,
+					DefaultUserActionResult:           prior.DefaultUserActionResult,
+					MessagingTemplates:                normalizedJsonNull(),
+					LocalizedMessagingTemplates:       types.MapNull(types.ObjectType{AttrTypes: messagingTemplateAttributeTypes}),
+					VerificationMethods:               prior.VerificationMethods,
+					PromptToEnrollVerificationMethods: prior.PromptToEnrollVerificationMethods,
+					DefaultVerificationMethod:         prior.DefaultVerificationMethod,
+					Tenant:                            prior.Tenant,
+				}
+
+				if prior.MessagingTemplates.ValueString() != "" {
+					templates, diags := messagingTemplatesMapFromJson(prior.MessagingTemplates.ValueString())
+					resp.Diagnostics.Append(diags...)
+					if resp.Diagnostics.HasError() {
+						return
+					}
+
+					upgraded.MessagingTemplates = normalizedJsonValueFrom(prior.MessagingTemplates.ValueString())
+					upgraded.LocalizedMessagingTemplates = templates
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+	}
+}
+
 func (r *actionConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan actionConfigurationResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -169,12 +259,20 @@ func (r *actionConfigurationResource) Create(ctx context.Context, req resource.C
 		actionConfigurationToCreate.PromptToEnrollVerificationMethods = authsignal.SetValue(promptToEnrollVerificationMethodsSlice)
 	}
 
-	actionConfiguration, _, err := r.client.CreateActionConfiguration(actionConfigurationToCreate)
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actionConfiguration, _, err := client.CreateActionConfiguration(ctx, actionConfigurationToCreate)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error creating action configuration",
-			"Could not create action configuration, unexpected error: "+err.Error(),
-		)
+			"Could not create action configuration",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 
@@ -197,7 +295,13 @@ func (r *actionConfigurationResource) Read(ctx context.Context, req resource.Rea
 		return
 	}
 
-	actionConfiguration, statusCode, err := r.client.GetActionConfiguration(state.ActionCode.ValueString())
+	client, diags := r.client.forTenant(state.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actionConfiguration, statusCode, err := client.GetActionConfiguration(ctx, state.ActionCode.ValueString())
 
 	if statusCode == 404 {
 		resp.State.RemoveResource(ctx)
@@ -240,9 +344,17 @@ func (r *actionConfigurationResource) Read(ctx context.Context, req resource.Rea
 	state.PromptToEnrollVerificationMethods = promptToEnrollVerificationMethodsList
 
 	if actionConfiguration.MessagingTemplates != nil {
-		state.MessagingTemplates = types.StringValue(string(messagingTemplatesJson))
+		localizedMessagingTemplates, diags := messagingTemplatesMapFromJson(string(messagingTemplatesJson))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.MessagingTemplates = normalizedJsonValueFrom(string(messagingTemplatesJson))
+		state.LocalizedMessagingTemplates = localizedMessagingTemplates
 	} else {
-		state.MessagingTemplates = types.StringNull()
+		state.MessagingTemplates = normalizedJsonNull()
+		state.LocalizedMessagingTemplates = types.MapNull(types.ObjectType{AttrTypes: messagingTemplateAttributeTypes})
 	}
 
 	if len(actionConfiguration.DefaultVerificationMethod) > 0 {
@@ -259,6 +371,11 @@ func (r *actionConfigurationResource) Read(ctx context.Context, req resource.Rea
 }
 
 func (r *actionConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan actionConfigurationResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -325,16 +442,24 @@ func (r *actionConfigurationResource) Update(ctx context.Context, req resource.U
 		actionConfigurationToUpdate.MessagingTemplates = authsignal.SetNull(messagingTemplatesJson)
 	}
 
-	_, _, err2 := r.client.UpdateActionConfiguration(plan.ActionCode.ValueString(), actionConfigurationToUpdate)
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, _, err2 := client.UpdateActionConfiguration(ctx, plan.ActionCode.ValueString(), actionConfigurationToUpdate)
 	if err2 != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error Updating Authsignal action configuration",
-			"Could not update action configuration, unexpected error: "+err2.Error(),
-		)
+			"Could not update action configuration",
+			err2,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 
-	updatedActionConfiguration, _, err := r.client.GetActionConfiguration(plan.ActionCode.ValueString())
+	updatedActionConfiguration, _, err := client.GetActionConfiguration(ctx, plan.ActionCode.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Authsignal action configuration",
@@ -356,6 +481,11 @@ func (r *actionConfigurationResource) Update(ctx context.Context, req resource.U
 }
 
 func (r *actionConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state actionConfigurationResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -363,18 +493,27 @@ func (r *actionConfigurationResource) Delete(ctx context.Context, req resource.D
 		return
 	}
 
-	_, _, err := r.client.DeleteActionConfiguration(state.ActionCode.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
+	client, diags := r.client.forTenant(state.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, statusCode, err := client.DeleteActionConfiguration(ctx, state.ActionCode.ValueString())
+	if err != nil && statusCode != 404 {
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error Deleting Authsignal action configuration",
-			"Could not delete action configuration, unexpected error: "+err.Error(),
-		)
+			"Could not delete action configuration",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 }
 
 func (r *actionConfigurationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("action_code"), req, resp)
+	id := r.client.importTenant(ctx, req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("action_code"), id)...)
 }
 
 func (r *actionConfigurationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -382,12 +521,12 @@ func (r *actionConfigurationResource) Configure(_ context.Context, req resource.
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -21,7 +21,7 @@ func TestAccActionConfigurationResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test", "action_code", "terraform-acceptance-test"),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test", "default_user_action_result", "ALLOW"),
-					resource.TestCheckResourceAttr("authsignal_action_configuration.test", "tenant_id", "ec3910e7-ab32-479e-b58b-36a122631d58"),
+					resource.TestCheckResourceAttr("authsignal_action_configuration.test", "tenant_id", testAccTenantId()),
 				),
 			},
 			{
@@ -39,7 +39,7 @@ func TestAccActionConfigurationResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-templates", "action_code", "terraform-acceptance-test-templates"),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-templates", "default_user_action_result", "ALLOW"),
-					resource.TestCheckResourceAttr("authsignal_action_configuration.test-templates", "tenant_id", "ec3910e7-ab32-479e-b58b-36a122631d58"),
+					resource.TestCheckResourceAttr("authsignal_action_configuration.test-templates", "tenant_id", testAccTenantId()),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-templates", "messaging_templates", "{\"en\":{\"defaultTemplate\":\"hello world\"}}"),
 				),
 			},
@@ -55,7 +55,7 @@ func TestAccActionConfigurationResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "action_code", "terraform-acceptance-test-verification-methods"),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "default_user_action_result", "ALLOW"),
-					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "tenant_id", "ec3910e7-ab32-479e-b58b-36a122631d58"),
+					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "tenant_id", testAccTenantId()),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "verification_methods.0", "EMAIL_OTP"),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "verification_methods.1", "EMAIL_MAGIC_LINK"),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "default_verification_method", "EMAIL_MAGIC_LINK"),
@@ -72,7 +72,7 @@ func TestAccActionConfigurationResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test", "action_code", "terraform-acceptance-test"),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test", "default_user_action_result", "BLOCK"),
-					resource.TestCheckResourceAttr("authsignal_action_configuration.test", "tenant_id", "ec3910e7-ab32-479e-b58b-36a122631d58"),
+					resource.TestCheckResourceAttr("authsignal_action_configuration.test", "tenant_id", testAccTenantId()),
 				),
 			},
 			{
@@ -90,7 +90,7 @@ func TestAccActionConfigurationResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-templates", "action_code", "terraform-acceptance-test-templates"),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-templates", "default_user_action_result", "ALLOW"),
-					resource.TestCheckResourceAttr("authsignal_action_configuration.test-templates", "tenant_id", "ec3910e7-ab32-479e-b58b-36a122631d58"),
+					resource.TestCheckResourceAttr("authsignal_action_configuration.test-templates", "tenant_id", testAccTenantId()),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-templates", "messaging_templates", "{\"fr\":{\"defaultTemplate\":\"bonjour\"}}"),
 				),
 			},
@@ -106,7 +106,7 @@ func TestAccActionConfigurationResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "action_code", "terraform-acceptance-test-verification-methods"),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "default_user_action_result", "ALLOW"),
-					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "tenant_id", "ec3910e7-ab32-479e-b58b-36a122631d58"),
+					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "tenant_id", testAccTenantId()),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "verification_methods.0", "AUTHENTICATOR_APP"),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "verification_methods.1", "EMAIL_MAGIC_LINK"),
 					resource.TestCheckResourceAttr("authsignal_action_configuration.test-verification-methods", "default_verification_method", "AUTHENTICATOR_APP"),
@@ -116,3 +116,88 @@ func TestAccActionConfigurationResource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccActionConfigurationResourceLocalizedMessagingTemplates(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+					resource "authsignal_action_configuration" "test-localized-templates" {
+						action_code = "terraform-acceptance-test-localized-templates"
+						default_user_action_result = "ALLOW"
+						localized_messaging_templates = {
+							en = {
+								default_template = "hello world"
+							}
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_action_configuration.test-localized-templates", "localized_messaging_templates.%", "1"),
+					resource.TestCheckResourceAttr("authsignal_action_configuration.test-localized-templates", "localized_messaging_templates.en.default_template", "hello world"),
+					resource.TestCheckResourceAttr("authsignal_action_configuration.test-localized-templates", "messaging_templates", "{\"en\":{\"defaultTemplate\":\"hello world\"}}"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "authsignal_action_configuration.test-localized-templates",
+				ImportState:                          true,
+				ImportStateId:                        "terraform-acceptance-test-localized-templates",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "action_code",
+			},
+			// Update and Read testing
+			{
+				Config: `
+					resource "authsignal_action_configuration" "test-localized-templates" {
+						action_code = "terraform-acceptance-test-localized-templates"
+						default_user_action_result = "ALLOW"
+						localized_messaging_templates = {
+							en = {
+								default_template = "hello world"
+							}
+							fr = {
+								default_template = "bonjour"
+							}
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_action_configuration.test-localized-templates", "localized_messaging_templates.%", "2"),
+					resource.TestCheckResourceAttr("authsignal_action_configuration.test-localized-templates", "localized_messaging_templates.fr.default_template", "bonjour"),
+					resource.TestCheckResourceAttr("authsignal_action_configuration.test-localized-templates", "messaging_templates", "{\"en\":{\"defaultTemplate\":\"hello world\"},\"fr\":{\"defaultTemplate\":\"bonjour\"}}"),
+				),
+			},
+			// The same templates as deprecated JSON plan no changes.
+			{
+				Config: `
+					resource "authsignal_action_configuration" "test-localized-templates" {
+						action_code = "terraform-acceptance-test-localized-templates"
+						default_user_action_result = "ALLOW"
+						messaging_templates = jsonencode({
+							"fr": { "defaultTemplate": "bonjour" },
+							"en": { "defaultTemplate": "hello world" }
+						})
+					}
+				`,
+				PlanOnly: true,
+			},
+			// Removing the templates
+			{
+				Config: `
+					resource "authsignal_action_configuration" "test-localized-templates" {
+						action_code = "terraform-acceptance-test-localized-templates"
+						default_user_action_result = "ALLOW"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("authsignal_action_configuration.test-localized-templates", "localized_messaging_templates.%"),
+					resource.TestCheckNoResourceAttr("authsignal_action_configuration.test-localized-templates", "messaging_templates"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &actionConfigurationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &actionConfigurationsDataSource{}
+)
+
+func NewActionConfigurationsDataSource() datasource.DataSource {
+	return &actionConfigurationsDataSource{}
+}
+
+type actionConfigurationsDataSource struct {
+	client *authsignalClient
+}
+
+type actionConfigurationsDataSourceModel struct {
+	ActionConfigurations []actionConfigurationDataSourceModel `tfsdk:"action_configurations"`
+	Tenant               types.String                         `tfsdk:"tenant"`
+}
+
+func (d *actionConfigurationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_action_configurations"
+}
+
+func (d *actionConfigurationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the action configurations on a tenant. Reading it fails until the Management API client can list them.",
+		Attributes: map[string]schema.Attribute{
+			"tenant": tenantDataSourceAttribute(),
+			"action_configurations": schema.ListNestedAttribute{
+				Description: "The tenant's action configurations, ordered by action code.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tenant": tenantListedAttribute(),
+						"action_code": schema.StringAttribute{
+							Description: "The name of the action that users perform which you will track. (e.g 'login')",
+							Computed:    true,
+						},
+						"default_user_action_result": schema.StringAttribute{
+							Description: "The default action behavior if no rules match. (i.e 'CHALLENGE').",
+							Computed:    true,
+						},
+						"last_action_created_at": schema.StringAttribute{
+							Description: "The date of when an action was last tracked for any user.",
+							Computed:    true,
+						},
+						"tenant_id": schema.StringAttribute{
+							Description: "The ID of your tenant. This can be found in the admin portal.",
+							Computed:    true,
+						},
+						"messaging_templates": schema.StringAttribute{
+							Description: "Optional messaging templates to be shown in Authsignal's pre-built UI.",
+							Computed:    true,
+						},
+						"verification_methods": schema.ListAttribute{
+							ElementType: types.StringType,
+							Description: "A list of permitted authenticators that can be used if the result of the action is 'CHALLENGE'.",
+							Computed:    true,
+						},
+						"prompt_to_enroll_verification_methods": schema.ListAttribute{
+							ElementType: types.StringType,
+							Description: "If this is set then users will be prompted to add a passkey after a challenge is completed.",
+							Computed:    true,
+						},
+						"default_verification_method": schema.StringAttribute{
+							Description: "Ignore the user's preference and choose which authenticator the Pre-built UI will present by default.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *actionConfigurationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data actionConfigurationsDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.client.forTenant(data.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actionConfigurations, _, err := client.ListActionConfigurations(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to List Authsignal ActionConfigurations", err.Error())
+		return
+	}
+
+	sort.SliceStable(actionConfigurations, func(a, b int) bool {
+		return actionConfigurations[a].ActionCode < actionConfigurations[b].ActionCode
+	})
+
+	data.ActionConfigurations = []actionConfigurationDataSourceModel{}
+
+	for _, actionConfiguration := range actionConfigurations {
+		actionConfigurationState, diags := actionConfigurationDataSourceModelFromManagementActionConfiguration(ctx, actionConfiguration)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		actionConfigurationState.Tenant = data.Tenant
+		data.ActionConfigurations = append(data.ActionConfigurations, actionConfigurationState)
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func actionConfigurationDataSourceModelFromManagementActionConfiguration(ctx context.Context, actionConfiguration managementActionConfiguration) (actionConfigurationDataSourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	verificationMethodsList, d := types.ListValueFrom(ctx, types.StringType, actionConfiguration.VerificationMethods)
+	diags.Append(d...)
+
+	promptToEnrollVerificationMethodsList, d := types.ListValueFrom(ctx, types.StringType, actionConfiguration.PromptToEnrollVerificationMethods)
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return actionConfigurationDataSourceModel{}, diags
+	}
+
+	actionConfigurationState := actionConfigurationDataSourceModel{
+		ActionCode:                        types.StringValue(actionConfiguration.ActionCode),
+		TenantId:                          types.StringValue(actionConfiguration.TenantId),
+		DefaultUserActionResult:           types.StringValue(actionConfiguration.DefaultUserActionResult),
+		LastActionCreatedAt:               types.StringValue(actionConfiguration.LastActionCreatedAt),
+		VerificationMethods:               verificationMethodsList,
+		PromptToEnrollVerificationMethods: promptToEnrollVerificationMethodsList,
+	}
+
+	if actionConfiguration.MessagingTemplates != nil {
+		messagingTemplatesJson, err := json.Marshal(actionConfiguration.MessagingTemplates)
+		if err != nil {
+			diags.AddError("Unable to marshal messaging templates", err.Error())
+			return actionConfigurationDataSourceModel{}, diags
+		}
+
+		actionConfigurationState.MessagingTemplates = types.StringValue(string(messagingTemplatesJson))
+	} else {
+		actionConfigurationState.MessagingTemplates = types.StringNull()
+	}
+
+	if len(actionConfiguration.DefaultVerificationMethod) > 0 {
+		actionConfigurationState.DefaultVerificationMethod = types.StringValue(actionConfiguration.DefaultVerificationMethod)
+	} else {
+		actionConfigurationState.DefaultVerificationMethod = types.StringNull()
+	}
+
+	return actionConfigurationState, diags
+}
+
+func (d *actionConfigurationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring Authsignal client")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*authsignalClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
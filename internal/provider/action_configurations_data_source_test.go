@@ -0,0 +1,21 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccActionConfigurationsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The Management API client cannot list them yet, so reading the data source fails.
+			{
+				Config:      `data "authsignal_action_configurations" "all" {}`,
+				ExpectError: regexp.MustCompile("has no call to list"),
+			},
+		},
+	})
+}
@@ -0,0 +1,912 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/authsignal/authsignal-management-go/v6"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &actionRulesResource{}
+	_ resource.ResourceWithConfigure      = &actionRulesResource{}
+	_ resource.ResourceWithImportState    = &actionRulesResource{}
+	_ resource.ResourceWithValidateConfig = &actionRulesResource{}
+	_ resource.ResourceWithModifyPlan     = &actionRulesResource{}
+)
+
+// How rules on the action that this resource does not manage are treated.
+const (
+	unmanagedRulesIgnore = "ignore"
+	unmanagedRulesAdopt  = "adopt"
+	unmanagedRulesDelete = "delete"
+)
+
+// actionRulesImportingKey marks, in private state, an import whose first read should take every rule
+// on the action.
+const actionRulesImportingKey = "importing"
+
+func NewActionRulesResource() resource.Resource {
+	return &actionRulesResource{}
+}
+
+type actionRulesResource struct {
+	client *authsignalClient
+}
+
+type actionRulesResourceModel struct {
+	ActionCode     types.String           `tfsdk:"action_code"`
+	UnmanagedRules types.String           `tfsdk:"unmanaged_rules"`
+	Rules          []actionRulesRuleModel `tfsdk:"rule"`
+	Tenant         types.String           `tfsdk:"tenant"`
+}
+
+type actionRulesRuleModel struct {
+	RuleId                            types.String        `tfsdk:"rule_id"`
+	Priority                          types.Int64         `tfsdk:"priority"`
+	Name                              types.String        `tfsdk:"name"`
+	Description                       types.String        `tfsdk:"description"`
+	IsActive                          types.Bool          `tfsdk:"is_active"`
+	Type                              types.String        `tfsdk:"type"`
+	VerificationMethods               types.List          `tfsdk:"verification_methods"`
+	PromptToEnrollVerificationMethods types.List          `tfsdk:"prompt_to_enroll_verification_methods"`
+	DefaultVerificationMethod         types.String        `tfsdk:"default_verification_method"`
+	Conditions                        normalizedJsonValue `tfsdk:"conditions"`
+}
+
+func (r *actionRulesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_action_rules"
+}
+
+func (r *actionRulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the rules of an action as one ordered list. Each rule's priority is its position in the list, so rules are reordered by moving their blocks.",
+		Attributes: map[string]schema.Attribute{
+			"tenant": tenantResourceAttribute(),
+			"action_code": schema.StringAttribute{
+				Description: "The name of the action that users perform which you will track. (e.g 'login')",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"unmanaged_rules": schema.StringAttribute{
+				Description: "What to do with rules on the action that are not managed by this resource. `ignore` leaves them alone. `adopt` takes over an existing rule with the same name as a `rule` block instead of creating a new one. `delete` adopts rules in the same way and deletes every other rule on the action. Allowed values: `ignore`, `adopt`, `delete`. Defaults to `ignore`. `adopt` and `delete` need to list the action's rules, which the Management API client cannot do yet, so only `ignore` is accepted for now.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(unmanagedRulesIgnore),
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{unmanagedRulesIgnore, unmanagedRulesAdopt, unmanagedRulesDelete}...),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"rule": schema.ListNestedBlock{
+				Description: "The action's rules, in the order they are applied. The first block gets priority 0, the second priority 1, and so on. Rule names must be unique, as they are how blocks are matched to rules when the list is reordered.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(100),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"rule_id": schema.StringAttribute{
+							Description: "The ID of the rule.",
+							Computed:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "The priority of the rule, taken from its position in the list.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "A string used to name the rule. Must be unique within the action.",
+							Required:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "A description of the rule.",
+							Optional:    true,
+						},
+						"is_active": schema.BoolAttribute{
+							Description: "Toggles whether or not the rule is actively applied.",
+							Required:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The result that the rule should return when the conditions are met. Allowed values: `ALLOW`, `CHALLENGE`, `REVIEW`, `BLOCK`.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf([]string{"ALLOW", "CHALLENGE", "REVIEW", "BLOCK"}...),
+							},
+						},
+						"verification_methods": schema.ListAttribute{
+							ElementType: types.StringType,
+							Description: "A list of permitted authenticators that can be used if the type of the rule is 'CHALLENGE'.",
+							Optional:    true,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(stringvalidator.OneOf(allowedVerificationMethods...)),
+							},
+						},
+						"prompt_to_enroll_verification_methods": schema.ListAttribute{
+							ElementType: types.StringType,
+							Description: "If this is set then users will be prompted to add a passkey after a challenge is completed. Allowed values: `[PASSKEY]`.",
+							Optional:    true,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(stringvalidator.OneOf([]string{"PASSKEY"}...)),
+							},
+						},
+						"default_verification_method": schema.StringAttribute{
+							Description: "Ignore the user's preference and choose which authenticator the Pre-built UI will present by default.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(allowedVerificationMethods...),
+							},
+						},
+						"conditions": schema.StringAttribute{
+							Description: "The logical conditions to match tracked actions against, as a JsonLogic string.",
+							CustomType:  normalizedJsonType{},
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *actionRulesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config actionRulesResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := map[string]int{}
+	for i, rule := range config.Rules {
+		if rule.Name.IsNull() || rule.Name.IsUnknown() {
+			continue
+		}
+
+		if first, ok := seen[rule.Name.ValueString()]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rule").AtListIndex(i).AtName("name"),
+				"Duplicate rule name",
+				fmt.Sprintf("The rule name %q is already used by rule %d. Rule names must be unique within authsignal_action_rules.", rule.Name.ValueString(), first),
+			)
+			continue
+		}
+
+		seen[rule.Name.ValueString()] = i
+	}
+
+	// adopt and delete find the action's other rules by listing them, which the Management API client
+	// cannot do yet.
+	if unmanagedRules := config.UnmanagedRules.ValueString(); unmanagedRules == unmanagedRulesAdopt || unmanagedRules == unmanagedRulesDelete {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("unmanaged_rules"),
+			"Unmanaged rules cannot be listed",
+			fmt.Sprintf("unmanaged_rules = %q needs the rules on the action that this resource does not manage, and %s. "+
+				"Use `ignore`, and import or delete the other rules separately.", unmanagedRules, listUnsupportedError{what: "the rules of an action"}.Error()),
+		)
+	}
+}
+
+// ModifyPlan lines each planned rule up with the rule of the same name in state, because Terraform
+// pairs list blocks by position and would otherwise carry a rule ID over to whichever block moved into
+// its slot. Priorities always come from list position.
+func (r *actionRulesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan actionRulesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stateRuleIds := map[string]types.String{}
+	managedRuleIds := map[string]bool{}
+	if !req.State.Raw.IsNull() {
+		var state actionRulesResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for _, rule := range state.Rules {
+			stateRuleIds[rule.Name.ValueString()] = rule.RuleId
+			managedRuleIds[rule.RuleId.ValueString()] = true
+		}
+	}
+
+	for i := range plan.Rules {
+		plan.Rules[i].Priority = types.Int64Value(int64(i))
+
+		if ruleId, ok := stateRuleIds[plan.Rules[i].Name.ValueString()]; ok && !plan.Rules[i].Name.IsUnknown() {
+			plan.Rules[i].RuleId = ruleId
+		} else {
+			plan.Rules[i].RuleId = types.StringUnknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || r.client == nil || plan.Tenant.IsUnknown() || plan.ActionCode.IsUnknown() || plan.UnmanagedRules.IsUnknown() {
+		return
+	}
+
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plannedNames := map[string]bool{}
+	for _, rule := range plan.Rules {
+		plannedNames[rule.Name.ValueString()] = true
+	}
+
+	// Rules this resource manages, or is about to adopt or delete, never conflict with its own.
+	unmanagedRules := plan.UnmanagedRules.ValueString()
+	owns := func(ruleId string, name string) bool {
+		switch unmanagedRules {
+		case unmanagedRulesDelete:
+			return true
+		case unmanagedRulesAdopt:
+			return managedRuleIds[ruleId] || plannedNames[name]
+		}
+		return managedRuleIds[ruleId]
+	}
+
+	for i, rule := range plan.Rules {
+		if rule.Name.IsUnknown() {
+			continue
+		}
+
+		resp.Diagnostics.Append(client.checkRulePriority(ctx, plannedRule{
+			actionCode:   plan.ActionCode.ValueString(),
+			ruleId:       rule.RuleId.ValueString(),
+			name:         rule.Name.ValueString(),
+			resource:     "authsignal_action_rules",
+			priority:     rule.Priority.ValueInt64(),
+			priorityPath: path.Root("rule").AtListIndex(i).AtName("priority"),
+			owns:         owns,
+		})...)
+	}
+}
+
+func (r *actionRulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan actionRulesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, diags := r.reconcile(ctx, &plan, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		// The rules created before the failure are kept in state, so the next apply carries on from them.
+		if len(rules) > 0 {
+			plan.Rules = rules
+			resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		}
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *actionRulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state actionRulesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.forTenant(state.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actionCode := state.ActionCode.ValueString()
+
+	importing, diags := req.Private.GetKey(ctx, actionRulesImportingKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A freshly imported action has no rules in state yet, so it takes every rule on the action.
+	if len(importing) > 0 {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, actionRulesImportingKey, nil)...)
+
+		rules, statusCode, err := client.ListRules(ctx, actionCode)
+		if statusCode == 404 {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading rules",
+				"Could not list rules for action code "+actionCode+": "+err.Error(),
+			)
+			return
+		}
+
+		sort.SliceStable(rules, func(a, b int) bool {
+			return rules[a].Priority < rules[b].Priority
+		})
+
+		for _, rule := range rules {
+			ruleState, diags := actionRuleFromManagementRule(ctx, rule)
+			resp.Diagnostics.Append(diags...)
+			state.Rules = append(state.Rules, ruleState)
+		}
+	} else {
+		rules := make([]actionRulesRuleModel, 0, len(state.Rules))
+
+		for _, ruleState := range state.Rules {
+			rule, statusCode, err := client.GetRule(ctx, actionCode, ruleState.RuleId.ValueString())
+
+			if statusCode == 404 {
+				continue
+			}
+
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Reading rule",
+					"Could not read rule code "+actionCode+"-"+ruleState.RuleId.ValueString()+": "+err.Error(),
+				)
+				return
+			}
+
+			refreshed, diags := actionRuleFromManagementRule(ctx, managementRule{
+				ActionCode:                        rule.ActionCode,
+				RuleId:                            rule.RuleId,
+				TenantId:                          rule.TenantId,
+				Name:                              rule.Name,
+				Description:                       rule.Description,
+				IsActive:                          rule.IsActive,
+				Priority:                          rule.Priority,
+				Type:                              rule.Type,
+				VerificationMethods:               rule.VerificationMethods,
+				PromptToEnrollVerificationMethods: rule.PromptToEnrollVerificationMethods,
+				DefaultVerificationMethod:         rule.DefaultVerificationMethod,
+				Conditions:                        rule.Conditions,
+			})
+			resp.Diagnostics.Append(diags...)
+
+			rules = append(rules, refreshed)
+		}
+
+		state.Rules = rules
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *actionRulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan actionRulesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state actionRulesResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, diags := r.reconcile(ctx, &plan, state.Rules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		// The rules as they were left by the failure, so the next plan shows what is still to do.
+		plan.Rules = rules
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *actionRulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state actionRulesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.forTenant(state.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, rule := range state.Rules {
+		_, statusCode, err := client.DeleteRule(ctx, state.ActionCode.ValueString(), rule.RuleId.ValueString())
+		if err != nil && statusCode != 404 {
+			resp.Diagnostics.Append(apiErrorDiagnostics(
+				"Error Deleting Authsignal rule",
+				"Could not delete rule "+rule.Name.ValueString(),
+				err,
+				resourceApiErrorFields(ctx, r, nil),
+			)...)
+			return
+		}
+	}
+}
+
+func (r *actionRulesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	actionCode := r.client.importTenant(ctx, req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("action_code"), actionCode)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("unmanaged_rules"), unmanagedRulesIgnore)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, actionRulesImportingKey, []byte(`true`))...)
+}
+
+func (r *actionRulesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*authsignalClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// maxRulePriority is the highest priority a rule can have. Priorities above the last rule in the list
+// are free to park a rule on while the others move.
+const maxRulePriority = 99
+
+// reconcile makes the action's rules match the plan, given the rules currently in state, and returns
+// the rules as they stand afterwards. When a request fails it returns what was done up to then, so that
+// rules already created or adopted are kept in state rather than orphaned on the tenant.
+//
+// Dropped rules are deleted first. Existing rules are then moved to their new priorities, and only
+// then are new rules created, so that no two rules share a priority along the way. A rule moves
+// straight to its new priority once that is free; rules that swap priorities go through a free
+// priority first. The plan's rule IDs are filled in as it goes.
+func (r *actionRulesResource) reconcile(ctx context.Context, plan *actionRulesResourceModel, stateRules []actionRulesRuleModel) ([]actionRulesRuleModel, diag.Diagnostics) {
+	client, diags := r.client.forTenant(plan.Tenant)
+	if diags.HasError() {
+		return stateRules, diags
+	}
+
+	actionCode := plan.ActionCode.ValueString()
+	unmanagedRules := plan.UnmanagedRules.ValueString()
+
+	// current holds the managed rules as they are on the tenant, by rule ID.
+	current := map[string]actionRulesRuleModel{}
+	for _, rule := range stateRules {
+		current[rule.RuleId.ValueString()] = rule
+	}
+
+	reconciled := func() []actionRulesRuleModel {
+		rules := make([]actionRulesRuleModel, 0, len(current))
+		added := map[string]bool{}
+		for _, rule := range append(append([]actionRulesRuleModel{}, plan.Rules...), stateRules...) {
+			ruleId := rule.RuleId.ValueString()
+			if currentRule, ok := current[ruleId]; ok && !added[ruleId] {
+				rules = append(rules, currentRule)
+				added[ruleId] = true
+			}
+		}
+		return rules
+	}
+
+	plannedIds := map[string]bool{}
+	for _, rule := range plan.Rules {
+		if !rule.RuleId.IsUnknown() && !rule.RuleId.IsNull() {
+			plannedIds[rule.RuleId.ValueString()] = true
+		}
+	}
+
+	for _, rule := range stateRules {
+		if plannedIds[rule.RuleId.ValueString()] {
+			continue
+		}
+
+		_, statusCode, err := client.DeleteRule(ctx, actionCode, rule.RuleId.ValueString())
+		if err != nil && statusCode != 404 {
+			diags.Append(apiErrorDiagnostics(
+				"Error Deleting Authsignal rule",
+				"Could not delete rule "+rule.Name.ValueString(),
+				err,
+				resourceApiErrorFields(ctx, r, nil),
+			)...)
+			return reconciled(), diags
+		}
+
+		delete(current, rule.RuleId.ValueString())
+	}
+
+	// Rules on the action that neither state nor plan know about, by name.
+	unmanagedByName := map[string]managementRule{}
+	if unmanagedRules != unmanagedRulesIgnore {
+		existingRules, _, err := client.ListRules(withFreshReads(ctx), actionCode)
+		if err != nil {
+			diags.AddError(
+				"Error Reading rules",
+				"Could not list rules for action code "+actionCode+": "+err.Error(),
+			)
+			return reconciled(), diags
+		}
+
+		for _, existingRule := range existingRules {
+			if _, managed := current[existingRule.RuleId]; managed || plannedIds[existingRule.RuleId] {
+				continue
+			}
+
+			if _, seen := unmanagedByName[existingRule.Name]; !seen {
+				unmanagedByName[existingRule.Name] = existingRule
+				continue
+			}
+
+			// A second unmanaged rule with the same name cannot be adopted, so it is only ever deleted.
+			if unmanagedRules == unmanagedRulesDelete {
+				if diags.Append(deleteUnmanagedRule(ctx, client, actionCode, existingRule)...); diags.HasError() {
+					return reconciled(), diags
+				}
+			}
+		}
+	}
+
+	for i := range plan.Rules {
+		rule := &plan.Rules[i]
+		rule.Priority = types.Int64Value(int64(i))
+
+		if !rule.RuleId.IsUnknown() && !rule.RuleId.IsNull() {
+			continue
+		}
+
+		existingRule, ok := unmanagedByName[rule.Name.ValueString()]
+		if !ok {
+			continue
+		}
+
+		adopted, ruleDiags := actionRuleFromManagementRule(ctx, existingRule)
+		if diags.Append(ruleDiags...); diags.HasError() {
+			return reconciled(), diags
+		}
+
+		delete(unmanagedByName, rule.Name.ValueString())
+		rule.RuleId = adopted.RuleId
+		current[existingRule.RuleId] = adopted
+	}
+
+	// The priorities in use on the action, and which rule holds each. Unmanaged rules that are left
+	// alone keep theirs.
+	occupied := map[int64]string{}
+	for ruleId, rule := range current {
+		occupied[rule.Priority.ValueInt64()] = ruleId
+	}
+	for _, existingRule := range unmanagedByName {
+		if unmanagedRules == unmanagedRulesDelete {
+			if diags.Append(deleteUnmanagedRule(ctx, client, actionCode, existingRule)...); diags.HasError() {
+				return reconciled(), diags
+			}
+			continue
+		}
+		occupied[existingRule.Priority] = existingRule.RuleId
+	}
+
+	updateRule := func(i int, body authsignal.Rule, updated actionRulesRuleModel) bool {
+		ruleId := updated.RuleId.ValueString()
+
+		_, _, err := client.UpdateRule(ctx, actionCode, ruleId, body)
+		if err != nil {
+			diags.Append(apiErrorDiagnostics(
+				"Error Updating Authsignal rule",
+				"Could not update rule "+updated.Name.ValueString(),
+				err,
+				resourceApiErrorFields(ctx, r, nil).atListIndex("rule", i),
+			)...)
+			return false
+		}
+
+		if previous := current[ruleId].Priority.ValueInt64(); occupied[previous] == ruleId {
+			delete(occupied, previous)
+		}
+		occupied[updated.Priority.ValueInt64()] = ruleId
+		current[ruleId] = updated
+		return true
+	}
+
+	moveToPlan := func(i int) bool {
+		body, ruleDiags := actionRuleBody(ctx, plan.Rules[i], int64(i), true)
+		if diags.Append(ruleDiags...); diags.HasError() {
+			return false
+		}
+		return updateRule(i, body, plan.Rules[i])
+	}
+
+	// Existing rules whose priority changes.
+	var moving []int
+	movingIds := map[string]bool{}
+	for i, rule := range plan.Rules {
+		if currentRule, ok := current[rule.RuleId.ValueString()]; ok && currentRule.Priority.ValueInt64() != int64(i) {
+			moving = append(moving, i)
+			movingIds[rule.RuleId.ValueString()] = true
+		}
+	}
+
+	for len(moving) > 0 {
+		var waiting []int
+		for _, i := range moving {
+			ruleId := plan.Rules[i].RuleId.ValueString()
+
+			// A rule waits while another moving rule still holds its new priority. One that is held by
+			// a rule that isn't moving would never be freed, so it moves anyway.
+			if holder, taken := occupied[int64(i)]; taken && holder != ruleId && movingIds[holder] {
+				waiting = append(waiting, i)
+				continue
+			}
+
+			if !moveToPlan(i) {
+				return reconciled(), diags
+			}
+			delete(movingIds, ruleId)
+		}
+
+		if len(waiting) < len(moving) {
+			moving = waiting
+			continue
+		}
+
+		// Every rule left waits on another, so one of them steps aside to a free priority.
+		i := waiting[0]
+		parked, ok := freeRulePriority(occupied, int64(len(plan.Rules)))
+		if !ok {
+			// With every priority taken, the rule has to share its new one for a moment.
+			if !moveToPlan(i) {
+				return reconciled(), diags
+			}
+			delete(movingIds, plan.Rules[i].RuleId.ValueString())
+			moving = waiting[1:]
+			continue
+		}
+
+		parkedRule := current[plan.Rules[i].RuleId.ValueString()]
+		parkedRule.Priority = types.Int64Value(parked)
+		if !updateRule(i, authsignal.Rule{Priority: authsignal.SetValue(parked)}, parkedRule) {
+			return reconciled(), diags
+		}
+		moving = waiting
+	}
+
+	for i := range plan.Rules {
+		rule := &plan.Rules[i]
+
+		if rule.RuleId.IsUnknown() || rule.RuleId.IsNull() {
+			ruleToCreate, ruleDiags := actionRuleBody(ctx, *rule, int64(i), false)
+			if diags.Append(ruleDiags...); diags.HasError() {
+				return reconciled(), diags
+			}
+
+			created, _, err := client.CreateRule(ctx, actionCode, ruleToCreate)
+			if err != nil {
+				diags.Append(apiErrorDiagnostics(
+					"Error creating rule",
+					"Could not create rule "+rule.Name.ValueString(),
+					err,
+					resourceApiErrorFields(ctx, r, nil).atListIndex("rule", i),
+				)...)
+				return reconciled(), diags
+			}
+
+			rule.RuleId = types.StringValue(created.RuleId)
+			current[created.RuleId] = *rule
+			continue
+		}
+
+		if actionRuleUnchanged(current[rule.RuleId.ValueString()], *rule) {
+			continue
+		}
+
+		if !moveToPlan(i) {
+			return reconciled(), diags
+		}
+	}
+
+	return reconciled(), diags
+}
+
+// freeRulePriority returns a priority no rule holds, from above the last planned rule, for a rule to
+// wait on while the others move.
+func freeRulePriority(occupied map[int64]string, from int64) (int64, bool) {
+	for priority := from; priority <= maxRulePriority; priority++ {
+		if _, taken := occupied[priority]; !taken {
+			return priority, true
+		}
+	}
+
+	return 0, false
+}
+
+func deleteUnmanagedRule(ctx context.Context, client *authsignalClient, actionCode string, rule managementRule) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	_, statusCode, err := client.DeleteRule(ctx, actionCode, rule.RuleId)
+	if err != nil && statusCode != 404 {
+		diags.Append(apiErrorDiagnostics(
+			"Error Deleting Authsignal rule",
+			"Could not delete unmanaged rule "+rule.Name,
+			err,
+			apiErrorFields{},
+		)...)
+	}
+
+	return diags
+}
+
+func actionRuleUnchanged(current actionRulesRuleModel, planned actionRulesRuleModel) bool {
+	return current.Priority.Equal(planned.Priority) &&
+		current.Name.Equal(planned.Name) &&
+		current.Description.Equal(planned.Description) &&
+		current.IsActive.Equal(planned.IsActive) &&
+		current.Type.Equal(planned.Type) &&
+		current.VerificationMethods.Equal(planned.VerificationMethods) &&
+		current.PromptToEnrollVerificationMethods.Equal(planned.PromptToEnrollVerificationMethods) &&
+		current.DefaultVerificationMethod.Equal(planned.DefaultVerificationMethod) &&
+		jsonSemanticallyEqual(current.Conditions.ValueString(), planned.Conditions.ValueString())
+}
+
+// actionRuleBody builds the request body for a rule. Updates send explicit nulls for unset fields so
+// that removing one from the configuration clears it on the rule.
+func actionRuleBody(ctx context.Context, rule actionRulesRuleModel, priority int64, isUpdate bool) (authsignal.Rule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	verificationMethodsSlice := make([]string, 0, len(rule.VerificationMethods.Elements()))
+	diags.Append(rule.VerificationMethods.ElementsAs(ctx, &verificationMethodsSlice, false)...)
+
+	promptToEnrollVerificationMethodsSlice := make([]string, 0, len(rule.PromptToEnrollVerificationMethods.Elements()))
+	diags.Append(rule.PromptToEnrollVerificationMethods.ElementsAs(ctx, &promptToEnrollVerificationMethodsSlice, false)...)
+
+	var conditionsJson authsignal.Condition
+	if err := json.Unmarshal([]byte(rule.Conditions.ValueString()), &conditionsJson); err != nil {
+		diags.AddError(
+			"Unable to marshal conditions",
+			err.Error(),
+		)
+	}
+
+	if diags.HasError() {
+		return authsignal.Rule{}, diags
+	}
+
+	body := authsignal.Rule{
+		Name:       authsignal.SetValue(rule.Name.ValueString()),
+		IsActive:   authsignal.SetValue(rule.IsActive.ValueBool()),
+		Priority:   authsignal.SetValue(priority),
+		Type:       authsignal.SetValue(rule.Type.ValueString()),
+		Conditions: authsignal.SetValue(conditionsJson),
+	}
+
+	if description := rule.Description.ValueString(); len(description) > 0 {
+		body.Description = authsignal.SetValue(description)
+	} else if isUpdate {
+		body.Description = authsignal.SetNull(description)
+	}
+
+	if defaultVerificationMethod := rule.DefaultVerificationMethod.ValueString(); len(defaultVerificationMethod) > 0 {
+		body.DefaultVerificationMethod = authsignal.SetValue(defaultVerificationMethod)
+	} else if isUpdate {
+		body.DefaultVerificationMethod = authsignal.SetNull(defaultVerificationMethod)
+	}
+
+	if len(verificationMethodsSlice) > 0 {
+		body.VerificationMethods = authsignal.SetValue(verificationMethodsSlice)
+	} else if isUpdate {
+		body.VerificationMethods = authsignal.SetNull(verificationMethodsSlice)
+	}
+
+	if len(promptToEnrollVerificationMethodsSlice) > 0 {
+		body.PromptToEnrollVerificationMethods = authsignal.SetValue(promptToEnrollVerificationMethodsSlice)
+	} else if isUpdate {
+		body.PromptToEnrollVerificationMethods = authsignal.SetNull(promptToEnrollVerificationMethodsSlice)
+	}
+
+	return body, diags
+}
+
+func actionRuleFromManagementRule(ctx context.Context, rule managementRule) (actionRulesRuleModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ruleState := actionRulesRuleModel{
+		RuleId:   types.StringValue(rule.RuleId),
+		Priority: types.Int64Value(rule.Priority),
+		Name:     types.StringValue(rule.Name),
+		IsActive: types.BoolValue(rule.IsActive),
+		Type:     types.StringValue(rule.Type),
+	}
+
+	// Empty lists are read back as null so they match a configuration that leaves them out.
+	ruleState.VerificationMethods = types.ListNull(types.StringType)
+	if len(rule.VerificationMethods) > 0 {
+		verificationMethodsList, listDiags := types.ListValueFrom(ctx, types.StringType, rule.VerificationMethods)
+		diags.Append(listDiags...)
+		ruleState.VerificationMethods = verificationMethodsList
+	}
+
+	ruleState.PromptToEnrollVerificationMethods = types.ListNull(types.StringType)
+	if len(rule.PromptToEnrollVerificationMethods) > 0 {
+		promptToEnrollVerificationMethodsList, listDiags := types.ListValueFrom(ctx, types.StringType, rule.PromptToEnrollVerificationMethods)
+		diags.Append(listDiags...)
+		ruleState.PromptToEnrollVerificationMethods = promptToEnrollVerificationMethodsList
+	}
+
+	if len(rule.Description) > 0 {
+		ruleState.Description = types.StringValue(rule.Description)
+	} else {
+		ruleState.Description = types.StringNull()
+	}
+
+	if len(rule.DefaultVerificationMethod) > 0 {
+		ruleState.DefaultVerificationMethod = types.StringValue(rule.DefaultVerificationMethod)
+	} else {
+		ruleState.DefaultVerificationMethod = types.StringNull()
+	}
+
+	ruleState.Conditions = normalizedJsonNull()
+	if rule.Conditions != nil {
+		conditionsJson, err := json.Marshal(rule.Conditions)
+		if err != nil {
+			diags.AddError(
+				"Unable to marshal conditions",
+				err.Error(),
+			)
+			return ruleState, diags
+		}
+
+		ruleState.Conditions = normalizedJsonValueFrom(string(conditionsJson))
+	}
+
+	return ruleState, diags
+}
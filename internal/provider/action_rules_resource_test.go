@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccActionRulesResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+				resource "authsignal_action_rules" "terraform-acc-tests" {
+					action_code = "terraform-acc-tests-action-rules"
+
+					rule {
+						name       = "block-anonymous"
+						type       = "BLOCK"
+						is_active  = false
+						conditions = jsonencode({ "==" : [{ "var" : "ip.isAnonymous" }, true] })
+					}
+
+					rule {
+						name                 = "challenge-new-device"
+						type                 = "CHALLENGE"
+						is_active            = false
+						verification_methods = ["EMAIL_OTP"]
+						conditions           = jsonencode({ "==" : [{ "var" : "device.isKnown" }, false] })
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests", "unmanaged_rules", "ignore"),
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests", "rule.#", "2"),
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests", "rule.0.name", "block-anonymous"),
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests", "rule.0.priority", "0"),
+					resource.TestCheckResourceAttrSet("authsignal_action_rules.terraform-acc-tests", "rule.0.rule_id"),
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests", "rule.1.name", "challenge-new-device"),
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests", "rule.1.priority", "1"),
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests", "rule.1.verification_methods.0", "EMAIL_OTP"),
+				),
+			},
+			// ImportState testing: importing lists the action's rules, which the client cannot do yet
+			{
+				ResourceName:  "authsignal_action_rules.terraform-acc-tests",
+				ImportState:   true,
+				ImportStateId: "terraform-acc-tests-action-rules",
+				ExpectError:   regexp.MustCompile("has no call to list"),
+			},
+			// Adopting unmanaged rules lists them too
+			{
+				Config: `
+				resource "authsignal_action_rules" "terraform-acc-tests" {
+					action_code     = "terraform-acc-tests-action-rules"
+					unmanaged_rules = "adopt"
+
+					rule {
+						name       = "block-anonymous"
+						type       = "BLOCK"
+						is_active  = false
+						conditions = jsonencode({ "==" : [{ "var" : "ip.isAnonymous" }, true] })
+					}
+				}
+				`,
+				ExpectError: regexp.MustCompile("Unmanaged rules cannot be listed"),
+			},
+			// Reorder, update and Read testing
+			{
+				Config: `
+				resource "authsignal_action_rules" "terraform-acc-tests" {
+					action_code = "terraform-acc-tests-action-rules"
+
+					rule {
+						name                 = "challenge-new-device"
+						type                 = "CHALLENGE"
+						is_active            = false
+						verification_methods = ["EMAIL_OTP", "PASSKEY"]
+						conditions           = jsonencode({ "==" : [{ "var" : "device.isKnown" }, false] })
+					}
+
+					rule {
+						name       = "block-anonymous"
+						type       = "BLOCK"
+						is_active  = false
+						conditions = jsonencode({ "==" : [{ "var" : "ip.isAnonymous" }, true] })
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests", "rule.#", "2"),
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests", "rule.0.name", "challenge-new-device"),
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests", "rule.0.priority", "0"),
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests", "rule.0.verification_methods.#", "2"),
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests", "rule.1.name", "block-anonymous"),
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests", "rule.1.priority", "1"),
+					testAccCheckNoPriorityOverlaps("terraform-acc-tests-action-rules"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccActionRulesResourcePartialFailure(t *testing.T) {
+	api := testAccRequireFakeApi(t)
+
+	config := `
+	resource "authsignal_action_rules" "terraform-acc-tests-partial" {
+		action_code = "terraform-acc-tests-action-rules-partial"
+
+		rule {
+			name       = "block-anonymous"
+			type       = "BLOCK"
+			is_active  = false
+			conditions = jsonencode({ "==" : [{ "var" : "ip.isAnonymous" }, true] })
+		}
+
+		rule {
+			name       = "review-new-device"
+			type       = "REVIEW"
+			is_active  = false
+			conditions = jsonencode({ "==" : [{ "var" : "device.isKnown" }, false] })
+		}
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The second rule fails to create, and the first is kept in state
+			{
+				PreConfig: func() {
+					api.failAfter(http.MethodPost, "/actions/terraform-acc-tests-action-rules-partial/rules", http.StatusBadRequest, 1)
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile("Could not create rule review-new-device"),
+			},
+			// The next apply replaces the rule it created rather than leaving it behind
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_action_rules.terraform-acc-tests-partial", "rule.#", "2"),
+					func(_ *terraform.State) error {
+						api.mu.Lock()
+						defer api.mu.Unlock()
+
+						if count := len(api.rules["terraform-acc-tests-action-rules-partial"]); count != 2 {
+							return fmt.Errorf("bad rule count on the tenant. expected: 2. got : %d", count)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckNoPriorityOverlaps checks that no two rules on the action ever held the same priority at
+// once, when running against the fake Management API.
+func testAccCheckNoPriorityOverlaps(actionCode string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if testAccFakeApi == nil {
+			return nil
+		}
+
+		for _, overlap := range testAccFakeApi.overlappingPriorities() {
+			if strings.HasPrefix(overlap, actionCode+" ") {
+				return fmt.Errorf("two rules briefly shared a priority: %s", overlap)
+			}
+		}
+		return nil
+	}
+}
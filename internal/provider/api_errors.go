@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// apiErrorFields says where the fields of a Management API request body are in a resource's
+// configuration, so that a validation error on a field can be reported on its attribute.
+type apiErrorFields struct {
+	// base is the path the request body corresponds to, and attributes its type.
+	base       path.Path
+	attributes attr.Type
+
+	// renames are the API fields, in the API's camelCase, whose attribute is not simply their name in
+	// snake_case.
+	renames map[string]string
+}
+
+// resourceSchemaTypes holds the type of each resource's schema, by the resource's Go type, so that
+// resourceApiErrorFields builds each schema once.
+var resourceSchemaTypes sync.Map
+
+// resourceApiErrorFields returns the apiErrorFields of a resource whose configuration is the request body.
+func resourceApiErrorFields(ctx context.Context, r resource.Resource, renames map[string]string) apiErrorFields {
+	attributes, ok := resourceSchemaTypes.Load(reflect.TypeOf(r))
+	if !ok {
+		var resp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+		attributes, _ = resourceSchemaTypes.LoadOrStore(reflect.TypeOf(r), resp.Schema.Type())
+	}
+
+	return apiErrorFields{
+		base:       path.Empty(),
+		attributes: attributes.(attr.Type),
+		renames:    renames,
+	}
+}
+
+// atListIndex returns the apiErrorFields of an element of the list attribute name, for a request
+// body that is one element, such as a rule of `authsignal_action_rules`.
+func (f apiErrorFields) atListIndex(name string, index int) apiErrorFields {
+	object, _ := f.attributes.(types.ObjectType)
+	list, _ := object.AttrTypes[name].(types.ListType)
+
+	return apiErrorFields{
+		base:       f.base.AtName(name).AtListIndex(index),
+		attributes: list.ElemType,
+		renames:    f.renames,
+	}
+}
+
+// apiError is an error response from the Management API. The API's errors are JSON objects such as
+//
+//	{"error": "invalid_request", "errorDescription": "...", "errors": [{"path": ["colors", "link"], "message": "..."}]}
+//
+// where `error` is a code, `errorDescription` says what is wrong, and `errors`, when there is one,
+// lists the invalid fields of the request body. The request ID comes from the response's headers.
+type apiError struct {
+	method     string
+	endpoint   string
+	statusCode int
+	body       string
+
+	code        string
+	description string
+	requestId   string
+	fields      []apiFieldError
+}
+
+// apiFieldError is the error on a single field of a request body.
+type apiFieldError struct {
+	path    []string
+	message string
+}
+
+// newApiError reads the error response res to a request to endpoint, whose body is body.
+func newApiError(method string, endpoint string, res *http.Response, body []byte) *apiError {
+	apiErr := &apiError{
+		method:     method,
+		endpoint:   endpoint,
+		statusCode: res.StatusCode,
+		body:       strings.TrimSpace(string(body)),
+		requestId:  responseRequestId(res.Header),
+	}
+
+	var response struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"errorDescription"`
+		Errors           []struct {
+			Path    []any  `json:"path"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return apiErr
+	}
+
+	apiErr.code = response.Error
+	apiErr.description = response.ErrorDescription
+
+	for _, field := range response.Errors {
+		fieldPath := []string{}
+		for _, segment := range field.Path {
+			switch segment := segment.(type) {
+			case string:
+				fieldPath = append(fieldPath, segment)
+			case float64:
+				fieldPath = append(fieldPath, strconv.Itoa(int(segment)))
+			}
+		}
+
+		if len(fieldPath) > 0 && field.Message != "" {
+			apiErr.fields = append(apiErr.fields, apiFieldError{path: fieldPath, message: field.Message})
+		}
+	}
+
+	return apiErr
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s %s returned %d: %s", e.method, e.endpoint, e.statusCode, e.body)
+}
+
+// apiErrorDiagnostics reports an error from the Management API. When the error is an *apiError in
+// the API's error shape, its description, error code and request ID are reported, and each invalid
+// field is reported on the attribute it came from. Otherwise the error is reported as it is.
+func apiErrorDiagnostics(summary string, detail string, err error, fields apiErrorFields) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) || (apiErr.description == "" && len(apiErr.fields) == 0) {
+		diags.AddError(summary, detail+", unexpected error: "+err.Error())
+		return diags
+	}
+
+	unmatched := []string{}
+	for _, field := range apiErr.fields {
+		attributePath, ok := fields.attributePath(field.path)
+		if !ok {
+			unmatched = append(unmatched, fmt.Sprintf("%s: %s", strings.Join(field.path, "."), field.message))
+			continue
+		}
+
+		diags.AddAttributeError(attributePath, summary, detail+": "+field.message+apiErrorReference(apiErr.code, apiErr.requestId))
+	}
+
+	if len(apiErr.fields) == 0 || len(unmatched) > 0 {
+		message := apiErr.description
+		if len(unmatched) > 0 {
+			message = strings.TrimSpace(message + "\n\n" + strings.Join(unmatched, "\n"))
+		}
+		diags.AddError(summary, detail+": "+message+apiErrorReference(apiErr.code, apiErr.requestId))
+	}
+
+	return diags
+}
+
+// apiErrorReference returns the error code and request ID to end an error's detail with, for looking
+// the error up in the API's documentation or with Authsignal's support.
+func apiErrorReference(code string, requestId string) string {
+	lines := []string{}
+	if code != "" {
+		lines = append(lines, "Error code: "+code)
+	}
+	if requestId != "" {
+		lines = append(lines, "Request ID: "+requestId)
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return "\n\n" + strings.Join(lines, "\n")
+}
+
+// attributePath returns the attribute an API field is in. A path that only partly matches the
+// schema returns the attribute of the part that does, such as the map an invalid key is in.
+func (f apiErrorFields) attributePath(apiPath []string) (path.Path, bool) {
+	attributePath := f.base
+	attributeType := f.attributes
+
+	for i := 0; i < len(apiPath); i++ {
+		segment := apiPath[i]
+
+		switch t := attributeType.(type) {
+		case types.ObjectType:
+			name, ok := f.renames[segment]
+			if !ok {
+				name = snakeCase(segment)
+			}
+
+			nested, ok := t.AttrTypes[name]
+			if !ok {
+				return attributePath, !attributePath.Equal(f.base)
+			}
+
+			attributePath = attributePath.AtName(name)
+			attributeType = nested
+		case types.MapType:
+			switch t.ElemType.(type) {
+			case types.ObjectType, types.MapType, types.ListType, types.SetType:
+				attributePath = attributePath.AtMapKey(segment)
+				attributeType = t.ElemType
+			default:
+				// Map keys such as `sms-code-entry.heading` may have dots in, which a path given as a
+				// dotted string splits, so the rest of the path is the key.
+				return attributePath.AtMapKey(strings.Join(apiPath[i:], ".")), true
+			}
+		case types.ListType:
+			index, err := strconv.Atoi(segment)
+			if err != nil {
+				return attributePath, !attributePath.Equal(f.base)
+			}
+
+			attributePath = attributePath.AtListIndex(index)
+			attributeType = t.ElemType
+		default:
+			return attributePath, !attributePath.Equal(f.base)
+		}
+	}
+
+	return attributePath, !attributePath.Equal(f.base)
+}
+
+// snakeCase turns an API field name such as `buttonPrimaryText` into an attribute name such as
+// `button_primary_text`.
+func snakeCase(name string) string {
+	var builder strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				builder.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// testApiError returns the error of a response with body, wrapped as a caller might wrap it.
+func testApiError(statusCode int, body string, header http.Header) error {
+	res := &http.Response{StatusCode: statusCode, Header: header}
+	return fmt.Errorf("unable to save: %w", newApiError(http.MethodPost, "/actions/login/rules", res, []byte(body)))
+}
+
+func TestApiErrorDiagnostics(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name   string
+		err    error
+		fields apiErrorFields
+		// paths are the attributes of the errors expected, with path.Empty() for one on no attribute.
+		paths   []path.Path
+		details []string
+	}{
+		{
+			name:    "not an API error",
+			err:     errors.New("connection refused"),
+			fields:  resourceApiErrorFields(ctx, &ruleResource{}, nil),
+			paths:   []path.Path{path.Empty()},
+			details: []string{"Could not save, unexpected error: connection refused"},
+		},
+		{
+			name:    "not in the API's error shape",
+			err:     testApiError(502, "<html>Bad Gateway</html>", nil),
+			fields:  resourceApiErrorFields(ctx, &ruleResource{}, nil),
+			paths:   []path.Path{path.Empty()},
+			details: []string{"Could not save, unexpected error: unable to save: POST /actions/login/rules returned 502: <html>Bad Gateway</html>"},
+		},
+		{
+			name:    "error without fields",
+			err:     testApiError(400, `{"error": "invalid_request", "errorDescription": "The action does not exist."}`, http.Header{"X-Request-Id": []string{"req-1"}}),
+			fields:  resourceApiErrorFields(ctx, &ruleResource{}, nil),
+			paths:   []path.Path{path.Empty()},
+			details: []string{"Could not save: The action does not exist.\n\nError code: invalid_request\nRequest ID: req-1"},
+		},
+		{
+			name:    "rule conditions",
+			err:     testApiError(400, `{"error": "invalid_request", "errors": [{"path": ["conditions", "and", 0], "message": "Unknown operator."}]}`, nil),
+			fields:  resourceApiErrorFields(ctx, &ruleResource{}, nil),
+			paths:   []path.Path{path.Root("conditions")},
+			details: []string{"Could not save: Unknown operator.\n\nError code: invalid_request"},
+		},
+		{
+			name:    "verification methods",
+			err:     testApiError(400, `{"error": "invalid_request", "errorDescription": "Invalid request", "errors": [{"path": ["verificationMethods", 1], "message": "Invalid enum value."}]}`, nil),
+			fields:  resourceApiErrorFields(ctx, &ruleResource{}, nil),
+			paths:   []path.Path{path.Root("verification_methods").AtListIndex(1)},
+			details: []string{"Could not save: Invalid enum value.\n\nError code: invalid_request"},
+		},
+		{
+			name:    "theme color",
+			err:     testApiError(400, `{"errorDescription": "Invalid request", "errors": [{"path": ["colors", "buttonPrimaryText"], "message": "Must be a hex color."}]}`, http.Header{"Apigw-Requestid": []string{"req-2"}}),
+			fields:  resourceApiErrorFields(ctx, &themeResource{}, nil),
+			paths:   []path.Path{path.Root("colors").AtName("button_primary_text")},
+			details: []string{"Could not save: Must be a hex color.\n\nRequest ID: req-2"},
+		},
+		{
+			name:    "message override",
+			err:     testApiError(400, `{"errorDescription": "Invalid request", "errors": [{"path": ["messageOverrides", "en", "sms-code-entry.heading"], "message": "Too long."}]}`, nil),
+			fields:  resourceApiErrorFields(ctx, &messageOverridesResource{}, messageOverridesApiFieldRenames),
+			paths:   []path.Path{path.Root("overrides").AtMapKey("en").AtMapKey("sms-code-entry.heading")},
+			details: []string{"Could not save: Too long."},
+		},
+		{
+			name:    "action rule",
+			err:     testApiError(400, `{"errorDescription": "Invalid request", "errors": [{"path": ["type"], "message": "Invalid enum value."}]}`, nil),
+			fields:  resourceApiErrorFields(ctx, &actionRulesResource{}, nil).atListIndex("rule", 2),
+			paths:   []path.Path{path.Root("rule").AtListIndex(2).AtName("type")},
+			details: []string{"Could not save: Invalid enum value."},
+		},
+		{
+			name:    "unknown field",
+			err:     testApiError(400, `{"errorDescription": "Invalid request", "errors": [{"path": ["unknownField"], "message": "Not allowed."}, {"path": ["name"], "message": "Required."}]}`, nil),
+			fields:  resourceApiErrorFields(ctx, &ruleResource{}, nil),
+			paths:   []path.Path{path.Root("name"), path.Empty()},
+			details: []string{"Could not save: Required.", "Could not save: Invalid request\n\nunknownField: Not allowed."},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			diags := apiErrorDiagnostics("Error saving", "Could not save", testCase.err, testCase.fields)
+
+			if len(diags) != len(testCase.paths) {
+				t.Fatalf("expected %d diagnostics. got : %v", len(testCase.paths), diags)
+			}
+
+			for i, d := range diags {
+				attributePath := path.Empty()
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					attributePath = withPath.Path()
+				}
+
+				if !attributePath.Equal(testCase.paths[i]) {
+					t.Fatalf("bad attribute. expected: %v, got : %v", testCase.paths[i], attributePath)
+				}
+
+				if d.Summary() != "Error saving" || d.Detail() != testCase.details[i] {
+					t.Fatalf("bad diagnostic. expected: %q, got : %q", testCase.details[i], d.Detail())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// apiSecretCommandTimeout is the longest api_secret_command may take to print the secret.
+const apiSecretCommandTimeout = time.Minute
+
+// apiSecretSource is where the API secret comes from: the secret itself, a file holding it, or a
+// command that prints it, either as its words or as one command line. At most one of them is set.
+type apiSecretSource struct {
+	value       string
+	file        string
+	command     []string
+	commandLine string
+
+	// path is the attribute to report a failure to read the secret under.
+	path path.Path
+}
+
+func (s apiSecretSource) isSet() bool {
+	return s.value != "" || s.file != "" || len(s.command) > 0 || s.commandLine != ""
+}
+
+// apiSecretEnvelope is what api_secret_command may print instead of the bare secret, so that it can
+// say when the secret expires.
+type apiSecretEnvelope struct {
+	ApiSecret string     `json:"api_secret"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// resolveApiSecret returns the API secret from its source, reading the file or running the command.
+func resolveApiSecret(ctx context.Context, source apiSecretSource) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case source.value != "":
+		return source.value, diags
+	case source.file != "":
+		content, err := os.ReadFile(source.file)
+		if err != nil {
+			diags.AddAttributeError(
+				source.path,
+				"Unable to Read Authsignal API Secret File",
+				fmt.Sprintf("The API secret file %s could not be read: %s", source.file, err.Error()),
+			)
+			return "", diags
+		}
+
+		apiSecret := strings.TrimSpace(string(content))
+		if apiSecret == "" {
+			diags.AddAttributeError(
+				source.path,
+				"Empty Authsignal API Secret File",
+				fmt.Sprintf("The API secret file %s is empty.", source.file),
+			)
+		}
+
+		return apiSecret, diags
+	case len(source.command) > 0:
+		return runApiSecretCommand(ctx, source.command, source.path)
+	case source.commandLine != "":
+		command, err := splitCommandLine(source.commandLine)
+		if err == nil && len(command) == 0 {
+			err = fmt.Errorf("it has no program")
+		}
+		if err != nil {
+			diags.AddAttributeError(
+				source.path,
+				"Invalid Authsignal API Secret Command",
+				fmt.Sprintf("The API secret command could not be split into its program and arguments: %s", err.Error()),
+			)
+			return "", diags
+		}
+
+		return runApiSecretCommand(ctx, command, source.path)
+	}
+
+	return "", diags
+}
+
+// runApiSecretCommand runs command and reads the API secret from what it prints: either the bare
+// secret, or a JSON object with the secret in `api_secret` and, optionally, when it expires in
+// `expires_at`, as an RFC 3339 timestamp.
+func runApiSecretCommand(ctx context.Context, command []string, commandPath path.Path) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ctx, cancel := context.WithTimeout(ctx, apiSecretCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// What the command printed is not included in errors, as it may be the secret.
+	if err := cmd.Run(); err != nil {
+		diags.AddAttributeError(
+			commandPath,
+			"Unable to Run Authsignal API Secret Command",
+			fmt.Sprintf("The API secret command %q failed: %s\n\n%s", command[0], err.Error(), strings.TrimSpace(stderr.String())),
+		)
+		return "", diags
+	}
+
+	output := strings.TrimSpace(stdout.String())
+	if !strings.HasPrefix(output, "{") {
+		if output == "" {
+			diags.AddAttributeError(
+				commandPath,
+				"Empty Authsignal API Secret",
+				fmt.Sprintf("The API secret command %q printed nothing.", command[0]),
+			)
+		}
+		return output, diags
+	}
+
+	var envelope apiSecretEnvelope
+	if err := json.Unmarshal([]byte(output), &envelope); err != nil {
+		diags.AddAttributeError(
+			commandPath,
+			"Invalid Authsignal API Secret Command Output",
+			fmt.Sprintf("The API secret command %q printed a JSON object that could not be decoded. It should be of the form "+
+				"`{\"api_secret\": \"...\", \"expires_at\": \"2030-01-01T00:00:00Z\"}`: %s", command[0], err.Error()),
+		)
+		return "", diags
+	}
+
+	if envelope.ApiSecret == "" {
+		diags.AddAttributeError(
+			commandPath,
+			"Empty Authsignal API Secret",
+			fmt.Sprintf("The API secret command %q printed a JSON object without an `api_secret`.", command[0]),
+		)
+		return "", diags
+	}
+
+	if envelope.ExpiresAt != nil && !envelope.ExpiresAt.After(time.Now()) {
+		diags.AddAttributeError(
+			commandPath,
+			"Expired Authsignal API Secret",
+			fmt.Sprintf("The API secret command %q printed a secret that expired at %s.", command[0], envelope.ExpiresAt.Format(time.RFC3339)),
+		)
+		return "", diags
+	}
+
+	return envelope.ApiSecret, diags
+}
+
+// splitCommandLine splits a command line into its words the way a POSIX shell does, so that an
+// argument can hold spaces when it is quoted or escaped. Single quotes keep everything up to the next
+// one as it is, and in double quotes a backslash only escapes `"`, `\`, `$` and a backquote. Nothing
+// is expanded, and the command is not run by a shell.
+func splitCommandLine(commandLine string) ([]string, error) {
+	words := []string{}
+
+	var word strings.Builder
+	inWord := false
+
+	runes := []rune(commandLine)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case r == '\\':
+			i++
+			if i == len(runes) {
+				return nil, fmt.Errorf("it ends with an unescaped backslash")
+			}
+			// A backslash before a newline joins the lines.
+			if runes[i] != '\n' {
+				word.WriteRune(runes[i])
+				inWord = true
+			}
+		case r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("it has an unterminated single quote")
+			}
+			word.WriteString(string(runes[i+1 : end]))
+			inWord = true
+			i = end
+		case r == '"':
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`", runes[i+1]) {
+					i++
+				}
+				word.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("it has an unterminated double quote")
+			}
+			inWord = true
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}
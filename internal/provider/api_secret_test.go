@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestResolveApiSecret(t *testing.T) {
+	ctx := context.Background()
+
+	file := filepath.Join(t.TempDir(), "api_secret")
+	if err := os.WriteFile(file, []byte("file-secret\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		source   apiSecretSource
+		expected string
+		err      bool
+	}{
+		{name: "value", source: apiSecretSource{value: "secret"}, expected: "secret"},
+		{name: "file", source: apiSecretSource{file: file}, expected: "file-secret"},
+		{name: "missing file", source: apiSecretSource{file: file + ".missing"}, err: true},
+		{name: "command", source: apiSecretSource{command: []string{"echo", "command-secret"}}, expected: "command-secret"},
+		{name: "envelope", source: apiSecretSource{command: []string{"echo", `{"api_secret": "envelope-secret", "expires_at": "2999-01-01T00:00:00Z"}`}}, expected: "envelope-secret"},
+		{name: "envelope without expiry", source: apiSecretSource{command: []string{"echo", `{"api_secret": "envelope-secret"}`}}, expected: "envelope-secret"},
+		{name: "expired envelope", source: apiSecretSource{command: []string{"echo", `{"api_secret": "envelope-secret", "expires_at": "2000-01-01T00:00:00Z"}`}}, err: true},
+		{name: "envelope without secret", source: apiSecretSource{command: []string{"echo", `{"expires_at": "2999-01-01T00:00:00Z"}`}}, err: true},
+		{name: "empty output", source: apiSecretSource{command: []string{"true"}}, err: true},
+		{name: "failing command", source: apiSecretSource{command: []string{"false"}}, err: true},
+		{name: "command line", source: apiSecretSource{commandLine: `echo 'command line secret'`}, expected: "command line secret"},
+		{name: "unterminated command line", source: apiSecretSource{commandLine: `echo "secret`}, err: true},
+		{name: "blank command line", source: apiSecretSource{commandLine: " "}, err: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.source.path = path.Root("api_secret")
+
+			apiSecret, diags := resolveApiSecret(ctx, testCase.source)
+			if diags.HasError() != testCase.err {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if !testCase.err && apiSecret != testCase.expected {
+				t.Fatalf("bad secret. expected: %q, got : %q", testCase.expected, apiSecret)
+			}
+		})
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	testCases := []struct {
+		commandLine string
+		expected    []string
+		err         bool
+	}{
+		{commandLine: "vault kv get -field=api_secret secret/authsignal", expected: []string{"vault", "kv", "get", "-field=api_secret", "secret/authsignal"}},
+		{commandLine: "  op  read\t'op://Dev Vault/Authsignal/api secret'  ", expected: []string{"op", "read", "op://Dev Vault/Authsignal/api secret"}},
+		{commandLine: `get-secret "C:\\Program Files\\secret" "say \"hi\" \n"`, expected: []string{"get-secret", `C:\Program Files\secret`, `say "hi" \n`}},
+		{commandLine: `get-secret my\ secret '' "" a'b'"c"`, expected: []string{"get-secret", "my secret", "", "", "abc"}},
+		{commandLine: `get-secret $HOME ~ *`, expected: []string{"get-secret", "$HOME", "~", "*"}},
+		{commandLine: "get-secret \\\n--json", expected: []string{"get-secret", "--json"}},
+		{commandLine: "", expected: []string{}},
+		{commandLine: `get-secret 'unterminated`, err: true},
+		{commandLine: `get-secret "unterminated`, err: true},
+		{commandLine: `get-secret \`, err: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.commandLine, func(t *testing.T) {
+			words, err := splitCommandLine(testCase.commandLine)
+			if (err != nil) != testCase.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !testCase.err && fmt.Sprintf("%q", words) != fmt.Sprintf("%q", testCase.expected) {
+				t.Fatalf("bad words. expected: %q. got : %q", testCase.expected, words)
+			}
+		})
+	}
+}
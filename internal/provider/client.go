@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/authsignal/authsignal-management-go/v6"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// authsignalClient is what the provider hands to its resources and data sources. It makes the calls of
+// the Management API client with a context, plus the value list item changes the client does not
+// cover yet.
+type authsignalClient struct {
+	host      string
+	tenantId  string
+	apiSecret string
+
+	// httpClient sends every request to the Management API, through the transports of the provider
+	// configuration the client belongs to.
+	httpClient *http.Client
+
+	// strictRulePriorities turns duplicate rule priorities from a warning into an error.
+	strictRulePriorities bool
+	rulePriorities       *rulePriorityRegistry
+
+	// valueListLocks serialise the changes to each value list's items, by alias, as each change
+	// sends the whole list.
+	valueListLocksMu sync.Mutex
+	valueListLocks   map[string]*sync.Mutex
+
+	// readOnly makes every create, update and delete fail before it reaches the Management API.
+	readOnly bool
+
+	// tenants are the other tenants in the provider's `tenants`, by key. The provider's own tenant
+	// is c itself, unless only other tenants are configured, in which case c.tenantId is empty.
+	tenants map[string]*authsignalClient
+}
+
+func newAuthsignalClient(host string, tenantId string, apiSecret string) *authsignalClient {
+	return &authsignalClient{
+		host:           strings.TrimSuffix(host, "/"),
+		tenantId:       tenantId,
+		apiSecret:      apiSecret,
+		httpClient:     http.DefaultClient,
+		rulePriorities: newRulePriorityRegistry(),
+	}
+}
+
+// requestContext returns the context to send a request to the Management API with, from the context
+// of the call it is for. The transports log the request through it, with c's host and tenant and
+// without c's API secret.
+func (c *authsignalClient) requestContext(ctx context.Context) context.Context {
+	if c.apiSecret != "" {
+		ctx = tflog.MaskLogStrings(ctx, c.apiSecret)
+	}
+	ctx = tflog.SetField(ctx, "authsignal_host", c.host)
+
+	return tflog.SetField(ctx, "authsignal_tenant_id", c.tenantId)
+}
+
+// hasOwnTenant reports whether the provider configures a tenant of its own, rather than only `tenants`.
+func (c *authsignalClient) hasOwnTenant() bool {
+	return c.tenantId != ""
+}
+
+// checkRulePriority reports other rules on the same action planned or existing with the same priority.
+func (c *authsignalClient) checkRulePriority(ctx context.Context, rule plannedRule) diag.Diagnostics {
+	return c.rulePriorities.check(ctx, c, rule, c.strictRulePriorities)
+}
+
+// checkWritable reports an error if the provider is read-only, so that a resource cannot change the
+// tenant. operation is what the resource was about to do, such as `create`.
+func (c *authsignalClient) checkWritable(operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if c != nil && c.readOnly {
+		diags.AddError(
+			"Authsignal Provider Is Read-Only",
+			fmt.Sprintf("The provider is configured with read_only, so it cannot %s resources. Nothing was sent to the Management API. "+
+				"Data sources and refreshing state still work. Unset read_only or AUTHSIGNAL_READ_ONLY to apply changes.", operation),
+		)
+	}
+
+	return diags
+}
+
+// verifyCredentials makes a cheap authenticated request, reading the tenant, and reports what is
+// wrong with the host, tenant_id or api_secret under attributes if it fails.
+func (c *authsignalClient) verifyCredentials(ctx context.Context, attributes path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	_, statusCode, err := c.GetTenant(ctx)
+	if err == nil {
+		return diags
+	}
+
+	return c.credentialsDiagnostics(attributes, statusCode, err)
+}
+
+// credentialsDiagnostics tells apart the ways verifyCredentials can fail by the status code of the
+// response, which is 0 when there was none.
+func (c *authsignalClient) credentialsDiagnostics(attributes path.Path, statusCode int, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	switch {
+	case statusCode == 0:
+		diags.AddAttributeError(
+			attributes.AtName("host"),
+			"Unable to Reach Authsignal API",
+			fmt.Sprintf("The provider could not connect to the Authsignal Management API at %s to verify its credentials. "+
+				"Check that the host or region is right and that the API is reachable from here, through any proxy.\n\n%s", c.host, err.Error()),
+		)
+	case statusCode == http.StatusUnauthorized:
+		diags.AddAttributeError(
+			attributes.AtName("api_secret"),
+			"Invalid Authsignal API Secret",
+			fmt.Sprintf("The Authsignal Management API at %s did not accept the API secret. "+
+				"Check that it is a Management API secret from the `Settings -> API keys` section of the admin portal, that it has not been rotated, "+
+				"and that the host or region is the one the tenant is in.", c.host),
+		)
+	case statusCode == http.StatusForbidden || statusCode == http.StatusNotFound:
+		diags.AddAttributeError(
+			attributes.AtName("tenant_id"),
+			"Wrong Authsignal Tenant",
+			fmt.Sprintf("The API secret is valid, but not for tenant %q. Check that the tenant_id and api_secret come from the same tenant.", c.tenantId),
+		)
+	default:
+		diags.AddAttributeError(
+			attributes.AtName("api_secret"),
+			"Unable to Verify Authsignal Credentials",
+			fmt.Sprintf("The Authsignal Management API at %s returned %d when verifying the provider's credentials: %s", c.host, statusCode, err.Error()),
+		)
+	}
+
+	return diags
+}
+
+// listUnsupportedError is what the list calls return. The Management API client has no calls that
+// list rules, action configurations, value lists or custom data points, and the provider does not guess
+// at list endpoints of its own, so anything that needs a list fails with this until the client has them.
+type listUnsupportedError struct {
+	what string
+}
+
+func (e listUnsupportedError) Error() string {
+	return fmt.Sprintf("the Authsignal Management API client has no call to list %s, so the provider cannot list them yet", e.what)
+}
+
+// isListUnsupported reports whether err is from a list call the Management API client does not have.
+func isListUnsupported(err error) bool {
+	var listErr listUnsupportedError
+	return errors.As(err, &listErr)
+}
+
+// managementRule is a rule as a list call returns it.
+type managementRule struct {
+	ActionCode                        string   `json:"actionCode"`
+	RuleId                            string   `json:"ruleId"`
+	TenantId                          string   `json:"tenantId"`
+	Name                              string   `json:"name"`
+	Description                       string   `json:"description"`
+	IsActive                          bool     `json:"isActive"`
+	Priority                          int64    `json:"priority"`
+	Type                              string   `json:"type"`
+	VerificationMethods               []string `json:"verificationMethods"`
+	PromptToEnrollVerificationMethods []string `json:"promptToEnrollVerificationMethods"`
+	DefaultVerificationMethod         string   `json:"defaultVerificationMethod"`
+	Conditions                        any      `json:"conditions"`
+}
+
+func (c *authsignalClient) ListRules(ctx context.Context, actionCode string) ([]managementRule, int, error) {
+	return nil, 0, listUnsupportedError{what: "the rules of an action"}
+}
+
+// managementActionConfiguration is an action configuration as a list call returns it.
+type managementActionConfiguration struct {
+	ActionCode                        string         `json:"actionCode"`
+	TenantId                          string         `json:"tenantId"`
+	DefaultUserActionResult           string         `json:"defaultUserActionResult"`
+	LastActionCreatedAt               string         `json:"lastActionCreatedAt"`
+	MessagingTemplates                map[string]any `json:"messagingTemplates"`
+	VerificationMethods               []string       `json:"verificationMethods"`
+	PromptToEnrollVerificationMethods []string       `json:"promptToEnrollVerificationMethods"`
+	DefaultVerificationMethod         string         `json:"defaultVerificationMethod"`
+}
+
+func (c *authsignalClient) ListActionConfigurations(ctx context.Context) ([]managementActionConfiguration, int, error) {
+	return nil, 0, listUnsupportedError{what: "action configurations"}
+}
+
+// managementValueList is a value list as a list call returns it.
+type managementValueList struct {
+	Name           string                     `json:"name"`
+	Alias          string                     `json:"alias"`
+	ItemType       string                     `json:"itemType"`
+	IsActive       bool                       `json:"isActive"`
+	ValueListItems []authsignal.ValueListItem `json:"valueListItems"`
+}
+
+func (c *authsignalClient) ListValueLists(ctx context.Context) ([]managementValueList, int, error) {
+	return nil, 0, listUnsupportedError{what: "value lists"}
+}
+
+// managementCustomDataPoint is a custom data point as a list call returns it.
+type managementCustomDataPoint struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	DataType    string `json:"dataType"`
+	ModelType   string `json:"modelType"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"isPublic"`
+}
+
+func (c *authsignalClient) ListCustomDataPoints(ctx context.Context) ([]managementCustomDataPoint, int, error) {
+	return nil, 0, listUnsupportedError{what: "custom data points"}
+}
+
+// lockValueList holds the lock on a value list's items until the returned function is called.
+func (c *authsignalClient) lockValueList(alias string) func() {
+	c.valueListLocksMu.Lock()
+	if c.valueListLocks == nil {
+		c.valueListLocks = map[string]*sync.Mutex{}
+	}
+	lock, ok := c.valueListLocks[alias]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.valueListLocks[alias] = lock
+	}
+	c.valueListLocksMu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// SetValueListItems replaces every item of a value list with items.
+func (c *authsignalClient) SetValueListItems(ctx context.Context, alias string, items []authsignal.ValueListItem) (*authsignal.ValueListResponse, int, error) {
+	defer c.lockValueList(alias)()
+
+	// A nil slice would be sent as null rather than as an empty list.
+	items = append([]authsignal.ValueListItem{}, items...)
+
+	return c.UpdateValueList(ctx, alias, authsignal.ValueList{ValueListItems: authsignal.SetValue(items)})
+}
+
+// valueListWriteAttempts is how many times ChangeValueListItems reads and writes a value list that
+// keeps changing in between.
+const valueListWriteAttempts = 5
+
+// ChangeValueListItems adds items to a value list and removes others, leaving the rest alone. change is
+// given the value list as it was read, and returns the items to add and remove. The Management API only
+// replaces a value list's items as a whole, so the list is read and written back with the change.
+//
+// When the Management API sends an ETag with the value list, the write is conditional on it, and a
+// value list changed elsewhere since the read is read again and the change retried, so that no item
+// added elsewhere is lost. Without one, such an item can be lost; the write holds a lock on the
+// alias, which only keeps apart the resources changing the same list in one Terraform run.
+func (c *authsignalClient) ChangeValueListItems(ctx context.Context, alias string, change func(valueList *authsignal.ValueListResponse) (added []authsignal.ValueListItem, removed []authsignal.ValueListItem)) (*authsignal.ValueListResponse, int, error) {
+	defer c.lockValueList(alias)()
+
+	for attempt := 1; ; attempt++ {
+		valueList, etag, statusCode, err := c.getValueListVersion(withFreshReads(ctx), alias)
+		if err != nil {
+			return nil, statusCode, err
+		}
+
+		added, removed := change(valueList)
+		if len(added) == 0 && len(removed) == 0 {
+			return valueList, statusCode, nil
+		}
+
+		removedKeys := map[string]bool{}
+		for _, item := range removed {
+			removedKeys[valueListItemKey(item)] = true
+		}
+
+		items := []authsignal.ValueListItem{}
+		seen := map[string]bool{}
+		for _, item := range append(valueList.ValueListItems, added...) {
+			key := valueListItemKey(item)
+			if removedKeys[key] || seen[key] {
+				continue
+			}
+			seen[key] = true
+			items = append(items, item)
+		}
+
+		updated, statusCode, err := c.updateValueListVersion(ctx, alias, etag, authsignal.ValueList{ValueListItems: authsignal.SetValue(items)})
+		if statusCode == http.StatusPreconditionFailed && attempt < valueListWriteAttempts {
+			tflog.Debug(ctx, "Authsignal value list changed since it was read, retrying", map[string]any{
+				"value_list_alias": alias,
+				"attempt":          attempt,
+			})
+			continue
+		}
+
+		return updated, statusCode, err
+	}
+}
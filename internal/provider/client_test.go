@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/authsignal/authsignal-management-go/v6"
+)
+
+func TestListsAreUnsupported(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { requests++ }))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := newAuthsignalClient(server.URL, "tenant", "secret")
+
+	_, _, rulesErr := client.ListRules(ctx, "login")
+	_, _, actionConfigurationsErr := client.ListActionConfigurations(ctx)
+	_, _, valueListsErr := client.ListValueLists(ctx)
+	_, _, customDataPointsErr := client.ListCustomDataPoints(ctx)
+
+	for _, err := range []error{rulesErr, actionConfigurationsErr, valueListsErr, customDataPointsErr} {
+		if !isListUnsupported(err) || !strings.Contains(err.Error(), "has no call to list") {
+			t.Errorf("expected a list unsupported error. got : %v", err)
+		}
+	}
+
+	if !isListUnsupported(fmt.Errorf("unable to list value lists: %w", valueListsErr)) {
+		t.Errorf("expected a wrapped list unsupported error to be recognised")
+	}
+
+	if requests != 0 {
+		t.Fatalf("expected no requests. got : %d", requests)
+	}
+}
+
+// valueListServer holds a single value list, whose items a PATCH replaces.
+func valueListServer(t *testing.T, items []any) (*httptest.Server, func() []any, func() []string) {
+	var mu sync.Mutex
+	requests := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+
+		if r.Method == http.MethodPatch {
+			var body map[string]json.RawMessage
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || r.Header.Get("Content-Type") != "application/json" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			if string(body["valueListItems"]) == "null" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			items = nil
+			_ = json.Unmarshal(body["valueListItems"], &items)
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"alias": "blocked-emails", "itemType": "string", "valueListItems": items})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []any {
+			mu.Lock()
+			defer mu.Unlock()
+			return items
+		}, func() []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return requests
+		}
+}
+
+// changeItems is a change for ChangeValueListItems that adds and removes the same items whatever the
+// value list holds.
+func changeItems(added []authsignal.ValueListItem, removed []authsignal.ValueListItem) func(*authsignal.ValueListResponse) ([]authsignal.ValueListItem, []authsignal.ValueListItem) {
+	return func(*authsignal.ValueListResponse) ([]authsignal.ValueListItem, []authsignal.ValueListItem) {
+		return added, removed
+	}
+}
+
+func TestChangeValueListItemsKeepsOtherItems(t *testing.T) {
+	server, items, requests := valueListServer(t, []any{"a", "b"})
+	client := newAuthsignalClient(server.URL, "tenant", "secret")
+
+	if _, _, err := client.ChangeValueListItems(context.Background(), "blocked emails", changeItems([]authsignal.ValueListItem{"c", "a"}, []authsignal.ValueListItem{"b"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fmt.Sprint(items()) != fmt.Sprint([]any{"a", "c"}) {
+		t.Fatalf("bad items. expected: %v. got : %v", []any{"a", "c"}, items())
+	}
+
+	expected := []string{"GET /value-lists/blocked%20emails", "PATCH /value-lists/blocked%20emails"}
+	if fmt.Sprint(requests()) != fmt.Sprint(expected) {
+		t.Fatalf("bad requests. expected: %v. got : %v", expected, requests())
+	}
+}
+
+func TestChangeValueListItemsIsSerialised(t *testing.T) {
+	server, items, _ := valueListServer(t, []any{})
+	client := newAuthsignalClient(server.URL, "tenant", "secret")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.ChangeValueListItems(context.Background(), "blocked-emails", changeItems([]authsignal.ValueListItem{fmt.Sprintf("item-%d", i)}, nil)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(items()) != 10 {
+		t.Fatalf("expected every item to be kept. got : %v", items())
+	}
+}
+
+func TestChangeValueListItemsRetriesAChangedValueList(t *testing.T) {
+	var mu sync.Mutex
+	items := []any{"a"}
+	version := 1
+	requests := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests = append(requests, r.Method+" "+r.Header.Get("If-Match"))
+
+		if r.Method == http.MethodPatch {
+			// Another workspace adds an item between the first read and write.
+			if len(requests) == 2 {
+				items = append(items, "added-elsewhere")
+				version++
+			}
+
+			if r.Header.Get("If-Match") != fmt.Sprintf(`"%d"`, version) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				_, _ = w.Write([]byte(`{"errorDescription":"The value list has changed"}`))
+				return
+			}
+
+			var body struct {
+				ValueListItems []any `json:"valueListItems"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			items = body.ValueListItems
+			version++
+		}
+
+		w.Header().Set("ETag", fmt.Sprintf(`"%d"`, version))
+		_ = json.NewEncoder(w).Encode(map[string]any{"alias": "blocked-emails", "itemType": "string", "valueListItems": items})
+	}))
+	defer server.Close()
+
+	client := newAuthsignalClient(server.URL, "tenant", "secret")
+
+	if _, _, err := client.ChangeValueListItems(context.Background(), "blocked-emails", changeItems([]authsignal.ValueListItem{"b"}, nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fmt.Sprint(items) != fmt.Sprint([]any{"a", "added-elsewhere", "b"}) {
+		t.Fatalf("bad items. expected: %v. got : %v", []any{"a", "added-elsewhere", "b"}, items)
+	}
+
+	expected := []string{"GET ", `PATCH "1"`, "GET ", `PATCH "2"`}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Fatalf("bad requests. expected: %v. got : %v", expected, requests)
+	}
+}
+
+func TestChangeValueListItemsGivesUpOnAValueListThatKeepsChanging(t *testing.T) {
+	patches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			patches++
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`{"errorDescription":"The value list has changed"}`))
+			return
+		}
+
+		w.Header().Set("ETag", `"1"`)
+		_, _ = w.Write([]byte(`{"alias":"blocked-emails","itemType":"string","valueListItems":[]}`))
+	}))
+	defer server.Close()
+
+	client := newAuthsignalClient(server.URL, "tenant", "secret")
+
+	_, statusCode, err := client.ChangeValueListItems(context.Background(), "blocked-emails", changeItems([]authsignal.ValueListItem{"b"}, nil))
+	if err == nil || statusCode != http.StatusPreconditionFailed {
+		t.Fatalf("expected a 412 error. got : %d, %v", statusCode, err)
+	}
+
+	if patches != valueListWriteAttempts {
+		t.Fatalf("bad write count. expected: %d. got : %d", valueListWriteAttempts, patches)
+	}
+}
+
+func TestChangeValueListItemsReadsOnceWithoutAChange(t *testing.T) {
+	server, _, requests := valueListServer(t, []any{"a"})
+	client := newAuthsignalClient(server.URL, "tenant", "secret")
+
+	var read *authsignal.ValueListResponse
+	_, _, err := client.ChangeValueListItems(context.Background(), "blocked-emails", func(valueList *authsignal.ValueListResponse) ([]authsignal.ValueListItem, []authsignal.ValueListItem) {
+		read = valueList
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if read == nil || read.ItemType != "string" {
+		t.Fatalf("expected the change to be given the value list. got : %v", read)
+	}
+
+	expected := []string{"GET /value-lists/blocked-emails"}
+	if fmt.Sprint(requests()) != fmt.Sprint(expected) {
+		t.Fatalf("bad requests. expected: %v. got : %v", expected, requests())
+	}
+}
+
+func TestSetValueListItemsSendsAnEmptyList(t *testing.T) {
+	server, items, _ := valueListServer(t, []any{"a"})
+	client := newAuthsignalClient(server.URL, "tenant", "secret")
+
+	if _, _, err := client.SetValueListItems(context.Background(), "blocked-emails", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(items()) != 0 {
+		t.Fatalf("expected no items. got : %v", items())
+	}
+}
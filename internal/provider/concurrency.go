@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// concurrencyTransport bounds how many requests to the Management API are in flight at once, across
+// every resource and data source, whatever Terraform's -parallelism. Requests beyond the limit queue
+// until one finishes, and how long they waited is logged through the request's context.
+type concurrencyTransport struct {
+	next  http.RoundTripper
+	slots chan struct{}
+}
+
+func newConcurrencyTransport(next http.RoundTripper, maxConcurrentRequests int) *concurrencyTransport {
+	return &concurrencyTransport{
+		next:  next,
+		slots: make(chan struct{}, maxConcurrentRequests),
+	}
+}
+
+func (t *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	default:
+		start := time.Now()
+
+		select {
+		case t.slots <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		tflog.Debug(req.Context(), "Waited for a free Authsignal API request slot", map[string]any{
+			"http_method":   req.Method,
+			"http_path":     req.URL.Path,
+			"queue_wait_ms": time.Since(start).Milliseconds(),
+			"max_requests":  cap(t.slots),
+		})
+	}
+	defer func() { <-t.slots }()
+
+	return t.next.RoundTrip(req)
+}
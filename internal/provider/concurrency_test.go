@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestConcurrencyTransport(t *testing.T) {
+	var inFlight, maxInFlight int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+
+		for {
+			seen := atomic.LoadInt64(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt64(&maxInFlight, seen, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	var outputMu sync.Mutex
+	ctx := tflogtest.RootLogger(context.Background(), &lockedWriter{w: &output, mu: &outputMu})
+
+	client := &http.Client{Transport: newConcurrencyTransport(http.DefaultTransport, 2)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v1/management/actions/login/rules", strings.NewReader("{}"))
+			res, err := client.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			res.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Fatalf("expected at most 2 requests in flight. got: %d", maxInFlight)
+	}
+
+	outputMu.Lock()
+	defer outputMu.Unlock()
+	if !strings.Contains(output.String(), `"queue_wait_ms"`) {
+		t.Fatalf("expected the queue wait to be logged: %s", output.String())
+	}
+}
+
+func TestConcurrencyTransportCanceled(t *testing.T) {
+	transport := newConcurrencyTransport(http.DefaultTransport, 1)
+	transport.slots <- struct{}{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://api.authsignal.test/v1/management/theme", nil)
+	if _, err := transport.RoundTrip(req); err == nil {
+		t.Fatalf("expected a canceled request waiting for a slot to fail")
+	}
+}
+
+// lockedWriter serializes writes from concurrent loggers.
+type lockedWriter struct {
+	w  *bytes.Buffer
+	mu *sync.Mutex
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
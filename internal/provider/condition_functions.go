@@ -0,0 +1,342 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// The condition functions build a rule's JsonLogic from the configuration, such as
+// `provider::authsignal::condition_all(provider::authsignal::condition_eq("ip.isAnonymous", false))`.
+// Every function returns the canonical encoding, the same one Read gives the conditions the API
+// returns, so the result can be compared with and assigned to `authsignal_rule.conditions` directly.
+// Conditions passed in are checked against the allowed operators when the function is called, which
+// is at plan time.
+
+var (
+	_ function.Function = &conditionAllFunction{}
+	_ function.Function = &conditionAnyFunction{}
+	_ function.Function = &conditionEqFunction{}
+	_ function.Function = &conditionInListFunction{}
+	_ function.Function = &conditionNotFunction{}
+	_ function.Function = &normalizeConditionsFunction{}
+)
+
+func NewConditionAllFunction() function.Function {
+	return &conditionAllFunction{}
+}
+
+func NewConditionAnyFunction() function.Function {
+	return &conditionAnyFunction{}
+}
+
+func NewConditionEqFunction() function.Function {
+	return &conditionEqFunction{}
+}
+
+func NewConditionInListFunction() function.Function {
+	return &conditionInListFunction{}
+}
+
+func NewConditionNotFunction() function.Function {
+	return &conditionNotFunction{}
+}
+
+func NewNormalizeConditionsFunction() function.Function {
+	return &normalizeConditionsFunction{}
+}
+
+type conditionAllFunction struct{}
+
+func (f *conditionAllFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "condition_all"
+}
+
+func (f *conditionAllFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Matches when every condition matches",
+		Description: "Combines JsonLogic conditions, such as those returned by `condition_eq`, into one that matches when every one of them matches: `{\"and\": [...]}`.",
+		VariadicParameter: function.StringParameter{
+			Name:        "conditions",
+			Description: "The JsonLogic conditions to combine. At least one is required.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *conditionAllFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	runConditionGroupFunction(ctx, "and", req, resp)
+}
+
+type conditionAnyFunction struct{}
+
+func (f *conditionAnyFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "condition_any"
+}
+
+func (f *conditionAnyFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Matches when any condition matches",
+		Description: "Combines JsonLogic conditions, such as those returned by `condition_eq`, into one that matches when any one of them matches: `{\"or\": [...]}`.",
+		VariadicParameter: function.StringParameter{
+			Name:        "conditions",
+			Description: "The JsonLogic conditions to combine. At least one is required.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *conditionAnyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	runConditionGroupFunction(ctx, "or", req, resp)
+}
+
+func runConditionGroupFunction(ctx context.Context, operator string, req function.RunRequest, resp *function.RunResponse) {
+	var conditions []string
+	resp.Error = req.Arguments.Get(ctx, &conditions)
+	if resp.Error != nil {
+		return
+	}
+
+	if len(conditions) == 0 {
+		resp.Error = function.NewFuncError("At least one condition is required.")
+		return
+	}
+
+	operands := make([]any, 0, len(conditions))
+	for i, condition := range conditions {
+		logic, funcErr := decodeConditionArgument(int64(i), condition)
+		if funcErr != nil {
+			resp.Error = function.ConcatFuncErrors(resp.Error, funcErr)
+			continue
+		}
+		operands = append(operands, logic)
+	}
+
+	if resp.Error != nil {
+		return
+	}
+
+	setConditionResult(ctx, map[string]any{operator: operands}, resp)
+}
+
+type conditionEqFunction struct{}
+
+func (f *conditionEqFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "condition_eq"
+}
+
+func (f *conditionEqFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Matches when a data point equals a value",
+		Description: "Returns a JsonLogic condition that matches when a tracked action's data point equals a value: `{\"==\": [{\"var\": \"<var>\"}, <value>]}`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "var",
+				Description: "The data point to compare, such as `ip.isAnonymous` or `custom.riskScore`.",
+			},
+			function.DynamicParameter{
+				Name:        "value",
+				Description: "The string, number or bool to compare the data point against.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *conditionEqFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var dataPoint string
+	var value types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &dataPoint, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	if dataPoint == "" {
+		resp.Error = function.NewArgumentFuncError(0, "The data point must not be empty.")
+		return
+	}
+
+	literal, funcErr := conditionLiteral(ctx, 1, value)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	setConditionResult(ctx, map[string]any{"==": []any{map[string]any{"var": dataPoint}, literal}}, resp)
+}
+
+type conditionInListFunction struct{}
+
+func (f *conditionInListFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "condition_in_list"
+}
+
+func (f *conditionInListFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Matches when a data point is in a value list",
+		Description: "Returns a JsonLogic condition that matches when a tracked action's data point is in a value list: `{\"in\": [{\"var\": \"<var>\"}, {\"var\": \"valueLists.<value_list_alias>\"}]}`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "var",
+				Description: "The data point to look for in the value list, such as `user.email`.",
+			},
+			function.StringParameter{
+				Name:        "value_list_alias",
+				Description: "The alias of the value list, such as the `alias` of an `authsignal_value_list`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *conditionInListFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var dataPoint string
+	var alias string
+	resp.Error = req.Arguments.Get(ctx, &dataPoint, &alias)
+	if resp.Error != nil {
+		return
+	}
+
+	if dataPoint == "" {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, "The data point must not be empty."))
+	}
+	if alias == "" {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, "The value list alias must not be empty."))
+	}
+	if resp.Error != nil {
+		return
+	}
+
+	setConditionResult(ctx, map[string]any{"in": []any{
+		map[string]any{"var": dataPoint},
+		map[string]any{"var": valueListVarPrefix + alias},
+	}}, resp)
+}
+
+type conditionNotFunction struct{}
+
+func (f *conditionNotFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "condition_not"
+}
+
+func (f *conditionNotFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Matches when a condition does not match",
+		Description: "Returns a JsonLogic condition that matches when the given condition does not: `{\"!\": [...]}`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "condition",
+				Description: "The JsonLogic condition to negate.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *conditionNotFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var condition string
+	resp.Error = req.Arguments.Get(ctx, &condition)
+	if resp.Error != nil {
+		return
+	}
+
+	logic, funcErr := decodeConditionArgument(0, condition)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	setConditionResult(ctx, map[string]any{"!": []any{logic}}, resp)
+}
+
+type normalizeConditionsFunction struct{}
+
+func (f *normalizeConditionsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_conditions"
+}
+
+func (f *normalizeConditionsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Checks and normalizes JsonLogic conditions",
+		Description: "Checks that a JsonLogic document only uses the operators a rule's conditions may use, and returns it in the canonical form the provider stores `authsignal_rule.conditions` in: without whitespace, with object keys sorted. Use it to compare conditions, or to check conditions written by hand or read from a file before they reach the API.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "json",
+				Description: "The JsonLogic conditions, as a JSON string.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *normalizeConditionsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var conditions string
+	resp.Error = req.Arguments.Get(ctx, &conditions)
+	if resp.Error != nil {
+		return
+	}
+
+	logic, funcErr := decodeConditionArgument(0, conditions)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	setConditionResult(ctx, logic, resp)
+}
+
+// decodeConditionArgument decodes the JsonLogic passed as the argument at position, and checks it only
+// uses the operators a rule's conditions may use.
+func decodeConditionArgument(position int64, conditions string) (any, *function.FuncError) {
+	var logic any
+	if err := json.Unmarshal([]byte(conditions), &logic); err != nil {
+		return nil, function.NewArgumentFuncError(position, "The condition is not valid JSON: "+err.Error())
+	}
+
+	var funcErr *function.FuncError
+	for _, d := range validateConditionOperators(path.Empty(), conditions) {
+		funcErr = function.ConcatFuncErrors(funcErr, function.NewArgumentFuncError(position, d.Detail()))
+	}
+
+	return logic, funcErr
+}
+
+// conditionLiteral converts the value passed as the argument at position to the JSON value to compare
+// a data point against.
+func conditionLiteral(ctx context.Context, position int64, value types.Dynamic) (any, *function.FuncError) {
+	switch value := value.UnderlyingValue().(type) {
+	case nil:
+		return nil, function.NewArgumentFuncError(position, "The value must be a string, number or bool.")
+	case basetypes.StringValue:
+		return value.ValueString(), nil
+	case basetypes.BoolValue:
+		return value.ValueBool(), nil
+	case basetypes.NumberValue:
+		number := value.ValueBigFloat()
+		if number.IsInt() {
+			if integer, accuracy := number.Int64(); accuracy == 0 {
+				return integer, nil
+			}
+		}
+		float, _ := number.Float64()
+		return float, nil
+	default:
+		return nil, function.NewArgumentFuncError(position, fmt.Sprintf("The value must be a string, number or bool. Got: %s", value.Type(ctx)))
+	}
+}
+
+// setConditionResult returns logic encoded the same way Read encodes the conditions the API returns.
+func setConditionResult(ctx context.Context, logic any, resp *function.RunResponse) {
+	conditions, err := json.Marshal(logic)
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to encode the conditions: " + err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, string(conditions))
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccConditionFunctions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// Provider-defined functions need Terraform 1.8.
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `output "conditions" {
+					value = provider::authsignal::condition_all(
+						provider::authsignal::condition_eq("ip.isAnonymous", true),
+						provider::authsignal::condition_not(provider::authsignal::condition_in_list("user.email", "allowed-emails")),
+					)
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("conditions", `{"and":[{"==":[{"var":"ip.isAnonymous"},true]},{"!":[{"in":[{"var":"user.email"},{"var":"valueLists.allowed-emails"}]}]}]}`),
+				),
+			},
+			{
+				Config: `output "conditions" {
+					value = provider::authsignal::normalize_conditions(jsonencode({ "or" : [{ "equals" : [{ "var" : "ip.isAnonymous" }, true] }] }))
+				}`,
+				ExpectError: regexp.MustCompile(`The operator "equals"`),
+			},
+		},
+	})
+}
+
+func TestConditionFunctions(t *testing.T) {
+	tuple := func(values ...string) attr.Value {
+		elementTypes := make([]attr.Type, len(values))
+		elements := make([]attr.Value, len(values))
+		for i, value := range values {
+			elementTypes[i] = types.StringType
+			elements[i] = types.StringValue(value)
+		}
+		return types.TupleValueMust(elementTypes, elements)
+	}
+
+	testCases := []struct {
+		name          string
+		function      function.Function
+		arguments     []attr.Value
+		expected      string
+		expectedError string
+	}{
+		{
+			name:      "eq bool",
+			function:  NewConditionEqFunction(),
+			arguments: []attr.Value{types.StringValue("ip.isAnonymous"), types.DynamicValue(types.BoolValue(false))},
+			expected:  `{"==":[{"var":"ip.isAnonymous"},false]}`,
+		},
+		{
+			name:      "eq integer",
+			function:  NewConditionEqFunction(),
+			arguments: []attr.Value{types.StringValue("custom.riskScore"), types.DynamicValue(types.NumberValue(big.NewFloat(5)))},
+			expected:  `{"==":[{"var":"custom.riskScore"},5]}`,
+		},
+		{
+			name:      "eq fraction",
+			function:  NewConditionEqFunction(),
+			arguments: []attr.Value{types.StringValue("custom.riskScore"), types.DynamicValue(types.NumberValue(big.NewFloat(0.5)))},
+			expected:  `{"==":[{"var":"custom.riskScore"},0.5]}`,
+		},
+		{
+			name:      "eq string",
+			function:  NewConditionEqFunction(),
+			arguments: []attr.Value{types.StringValue("ip.countryCode"), types.DynamicValue(types.StringValue("NZ"))},
+			expected:  `{"==":[{"var":"ip.countryCode"},"NZ"]}`,
+		},
+		{
+			name:          "eq list",
+			function:      NewConditionEqFunction(),
+			arguments:     []attr.Value{types.StringValue("ip.countryCode"), types.DynamicValue(tuple("NZ"))},
+			expectedError: "must be a string, number or bool",
+		},
+		{
+			name:          "eq empty var",
+			function:      NewConditionEqFunction(),
+			arguments:     []attr.Value{types.StringValue(""), types.DynamicValue(types.BoolValue(true))},
+			expectedError: "must not be empty",
+		},
+		{
+			name:      "in list",
+			function:  NewConditionInListFunction(),
+			arguments: []attr.Value{types.StringValue("user.email"), types.StringValue("blocked-emails")},
+			expected:  `{"in":[{"var":"user.email"},{"var":"valueLists.blocked-emails"}]}`,
+		},
+		{
+			name:      "all",
+			function:  NewConditionAllFunction(),
+			arguments: []attr.Value{tuple(`{"==": [{"var": "ip.isAnonymous"}, true]}`, `{"in":[{"var":"user.email"},{"var":"valueLists.blocked-emails"}]}`)},
+			expected:  `{"and":[{"==":[{"var":"ip.isAnonymous"},true]},{"in":[{"var":"user.email"},{"var":"valueLists.blocked-emails"}]}]}`,
+		},
+		{
+			name:      "any",
+			function:  NewConditionAnyFunction(),
+			arguments: []attr.Value{tuple(`{"==":[{"var":"ip.isAnonymous"},true]}`)},
+			expected:  `{"or":[{"==":[{"var":"ip.isAnonymous"},true]}]}`,
+		},
+		{
+			name:          "all without conditions",
+			function:      NewConditionAllFunction(),
+			arguments:     []attr.Value{tuple()},
+			expectedError: "At least one condition is required",
+		},
+		{
+			name:          "any with invalid JSON",
+			function:      NewConditionAnyFunction(),
+			arguments:     []attr.Value{tuple(`{"==":[{"var":"ip.isAnonymous"},true]}`, `{"==":`)},
+			expectedError: "not valid JSON",
+		},
+		{
+			name:      "not",
+			function:  NewConditionNotFunction(),
+			arguments: []attr.Value{types.StringValue(`{"==":[{"var":"ip.isAnonymous"},true]}`)},
+			expected:  `{"!":[{"==":[{"var":"ip.isAnonymous"},true]}]}`,
+		},
+		{
+			name:          "not with an unsupported operator",
+			function:      NewConditionNotFunction(),
+			arguments:     []attr.Value{types.StringValue(`{"equals":[{"var":"ip.isAnonymous"},true]}`)},
+			expectedError: `The operator "equals" at $.equals is not a supported JsonLogic operator`,
+		},
+		{
+			name:      "normalize",
+			function:  NewNormalizeConditionsFunction(),
+			arguments: []attr.Value{types.StringValue("{\n  \"and\": [ {\"==\": [ {\"var\": \"ip.isAnonymous\"}, 1.0 ]} ]\n}")},
+			expected:  `{"and":[{"==":[{"var":"ip.isAnonymous"},1]}]}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := function.RunRequest{Arguments: function.NewArgumentsData(testCase.arguments)}
+			resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+			testCase.function.Run(context.Background(), req, &resp)
+
+			if testCase.expectedError != "" {
+				if resp.Error == nil || !strings.Contains(resp.Error.Error(), testCase.expectedError) {
+					t.Fatalf("expected an error containing %q, got: %v", testCase.expectedError, resp.Error)
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %v", resp.Error)
+			}
+
+			if got := resp.Result.Value().(types.String).ValueString(); got != testCase.expected {
+				t.Fatalf("bad conditions. expected: %s. got : %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestConditionFunctionDefinitions(t *testing.T) {
+	ctx := context.Background()
+
+	for _, newFunction := range New("test")().(*authsignalProvider).Functions(ctx) {
+		f := newFunction()
+
+		var metadata function.MetadataResponse
+		f.Metadata(ctx, function.MetadataRequest{}, &metadata)
+
+		var definition function.DefinitionResponse
+		f.Definition(ctx, function.DefinitionRequest{}, &definition)
+
+		var validation function.DefinitionValidateResponse
+		definition.Definition.ValidateImplementation(ctx, function.DefinitionValidateRequest{FuncName: metadata.Name}, &validation)
+		if validation.Diagnostics.HasError() {
+			t.Fatalf("invalid definition of %s: %v", metadata.Name, validation.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// The credentials file holds named profiles of the settings that select a tenant, so that they need
+// not be in the configuration or the environment. It is an INI file such as:
+//
+//	[default]
+//	region     = us
+//	tenant_id  = 123
+//	api_secret = ...
+//
+//	[staging]
+//	host       = https://api.authsignal.com/v1/management
+//	tenant_id  = 456
+//	api_secret = ...
+//
+// Each setting is taken from the configuration first, then the environment, then the profile.
+
+// defaultCredentialsProfile is the profile used when neither `profile` nor AUTHSIGNAL_PROFILE is set.
+const defaultCredentialsProfile = "default"
+
+// credentialsProfileKeys are the settings a profile may have.
+var credentialsProfileKeys = map[string]bool{
+	"host":       true,
+	"region":     true,
+	"tenant_id":  true,
+	"api_secret": true,
+
+	"api_secret_file": true,
+	// api_secret_command is a command line, split into the program and its arguments as a shell would.
+	"api_secret_command": true,
+}
+
+// credentialsProfile is a profile of the credentials file.
+type credentialsProfile struct {
+	Host             string
+	Region           string
+	TenantID         string
+	ApiSecret        string
+	ApiSecretFile    string
+	ApiSecretCommand string
+}
+
+// defaultCredentialsFile returns `~/.authsignal/credentials`, or an empty string if there is no home
+// directory to find it in.
+func defaultCredentialsFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, ".authsignal", "credentials")
+}
+
+// loadCredentialsProfile reads a profile from the credentials file. A missing file or profile is only
+// an error when the profile was asked for by name; otherwise there is simply no profile, and it
+// returns nil.
+func loadCredentialsProfile(file string, profile string) (*credentialsProfile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	explicit := profile != ""
+	if !explicit {
+		profile = defaultCredentialsProfile
+	}
+
+	if file == "" {
+		file = defaultCredentialsFile()
+	} else if rest, ok := strings.CutPrefix(file, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			file = filepath.Join(home, rest)
+		}
+	}
+
+	if file == "" {
+		if explicit {
+			diags.AddAttributeError(
+				path.Root("profile"),
+				"Missing Authsignal Credentials File",
+				fmt.Sprintf("The profile %q was selected, but there is no home directory to find the credentials file in. Set credentials_file or the AUTHSIGNAL_CREDENTIALS_FILE environment variable.", profile),
+			)
+		}
+		return nil, diags
+	}
+
+	content, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return nil, diags
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("credentials_file"),
+			"Unable to Read Authsignal Credentials File",
+			fmt.Sprintf("The profile %q was selected, but the credentials file %s could not be read: %s", profile, file, err.Error()),
+		)
+		return nil, diags
+	}
+
+	profiles, err := parseCredentialsFile(content)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("credentials_file"),
+			"Invalid Authsignal Credentials File",
+			fmt.Sprintf("The credentials file %s is not valid: %s", file, err.Error()),
+		)
+		return nil, diags
+	}
+
+	settings, ok := profiles[profile]
+	if !ok {
+		if explicit {
+			diags.AddAttributeError(
+				path.Root("profile"),
+				"Unknown Authsignal Credentials Profile",
+				fmt.Sprintf("The credentials file %s has no profile %q. Profiles in the file: %s.", file, profile, strings.Join(sortedKeys(profiles), ", ")),
+			)
+		}
+		return nil, diags
+	}
+
+	return &credentialsProfile{
+		Host:             settings["host"],
+		Region:           settings["region"],
+		TenantID:         settings["tenant_id"],
+		ApiSecret:        settings["api_secret"],
+		ApiSecretFile:    settings["api_secret_file"],
+		ApiSecretCommand: settings["api_secret_command"],
+	}, diags
+}
+
+// parseCredentialsFile parses the profiles of a credentials file, by name. Blank lines and lines
+// starting with `#` or `;` are ignored, and values other than api_secret_command may be quoted.
+func parseCredentialsFile(content []byte) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var profile map[string]string
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "["):
+			name, ok := strings.CutSuffix(line[1:], "]")
+			name = strings.TrimSpace(name)
+			if !ok || name == "" {
+				return nil, fmt.Errorf("line %d: expected a profile name in brackets, such as [default]", lineNumber)
+			}
+			if _, ok := profiles[name]; ok {
+				return nil, fmt.Errorf("line %d: the profile %q is defined more than once", lineNumber, name)
+			}
+
+			profile = map[string]string{}
+			profiles[name] = profile
+		default:
+			key, value, ok := strings.Cut(line, "=")
+			if !ok {
+				return nil, fmt.Errorf("line %d: expected a setting, such as tenant_id = 123", lineNumber)
+			}
+			if profile == nil {
+				return nil, fmt.Errorf("line %d: settings must be under a profile, such as [default]", lineNumber)
+			}
+
+			key = strings.TrimSpace(key)
+			if !credentialsProfileKeys[key] {
+				return nil, fmt.Errorf("line %d: unknown setting %q, expected one of: %s", lineNumber, key, strings.Join(sortedKeys(credentialsProfileKeys), ", "))
+			}
+
+			// A command line keeps its quotes, as they separate its arguments.
+			value = strings.TrimSpace(value)
+			if key != "api_secret_command" && len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+				value = value[1 : len(value)-1]
+			}
+
+			profile[key] = value
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseCredentialsFile(t *testing.T) {
+	profiles, err := parseCredentialsFile([]byte(`
+# Local tenants
+[default]
+region     = us
+tenant_id  = 123
+api_secret = "default-secret"
+
+; Staging
+[ staging ]
+host = https://api.authsignal.com/v1/management
+tenant_id = '456'
+api_secret = a=b
+
+[vault]
+api_secret_command = 'my vault' read 'secret/authsignal prod'
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := profiles["default"]; got["region"] != "us" || got["tenant_id"] != "123" || got["api_secret"] != "default-secret" {
+		t.Fatalf("bad default profile: %v", got)
+	}
+
+	if got := profiles["staging"]; got["host"] != "https://api.authsignal.com/v1/management" || got["tenant_id"] != "456" || got["api_secret"] != "a=b" {
+		t.Fatalf("bad staging profile: %v", got)
+	}
+
+	if got := profiles["vault"]; got["api_secret_command"] != `'my vault' read 'secret/authsignal prod'` {
+		t.Fatalf("bad vault profile: %v", got)
+	}
+
+	invalid := []string{
+		"tenant_id = 123",
+		"[default]\ntenant_id",
+		"[default]\ntenant = 123",
+		"[default\ntenant_id = 123",
+		"[default]\n[default]",
+	}
+
+	for _, content := range invalid {
+		if _, err := parseCredentialsFile([]byte(content)); err == nil {
+			t.Fatalf("expected an error for %q", content)
+		}
+	}
+}
+
+func TestLoadCredentialsProfile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(file, []byte("[default]\ntenant_id = 123\n\n[staging]\ntenant_id = 456\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	profile, diags := loadCredentialsProfile(file, "")
+	if diags.HasError() || profile == nil || profile.TenantID != "123" {
+		t.Fatalf("expected the default profile. got: %v, %v", profile, diags)
+	}
+
+	profile, diags = loadCredentialsProfile(file, "staging")
+	if diags.HasError() || profile == nil || profile.TenantID != "456" {
+		t.Fatalf("expected the staging profile. got: %v, %v", profile, diags)
+	}
+
+	if _, diags := loadCredentialsProfile(file, "production"); !diags.HasError() {
+		t.Fatalf("expected an error for a profile not in the file")
+	}
+
+	missing := filepath.Join(t.TempDir(), "credentials")
+
+	profile, diags = loadCredentialsProfile(missing, "")
+	if diags.HasError() || profile != nil {
+		t.Fatalf("expected no profile without a credentials file. got: %v, %v", profile, diags)
+	}
+
+	if _, diags := loadCredentialsProfile(missing, "staging"); !diags.HasError() {
+		t.Fatalf("expected an error for a profile without a credentials file")
+	}
+}
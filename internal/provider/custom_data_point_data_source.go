@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/authsignal/authsignal-management-go/v6"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -21,7 +20,7 @@ func NewCustomDataPointDataSource() datasource.DataSource {
 }
 
 type customDataPointDataSource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 type customDataPointDataSourceModel struct {
@@ -31,6 +30,7 @@ type customDataPointDataSourceModel struct {
 	ModelType   types.String `tfsdk:"model_type"`
 	Description types.String `tfsdk:"description"`
 	IsPublic    types.Bool   `tfsdk:"is_public"`
+	Tenant      types.String `tfsdk:"tenant"`
 }
 
 func (d *customDataPointDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -40,6 +40,7 @@ func (d *customDataPointDataSource) Metadata(_ context.Context, req datasource.M
 func (d *customDataPointDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantDataSourceAttribute(),
 			"id": schema.StringAttribute{
 				Description: "The id of the custom data point.",
 				Required:    true,
@@ -76,13 +77,20 @@ func (d *customDataPointDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
-	customDataPoint, _, err := d.client.GetCustomDataPoint(data.Id.ValueString())
+	client, diags := d.client.forTenant(data.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	customDataPoint, _, err := client.GetCustomDataPoint(ctx, data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to Read Authsignal ValueList", err.Error())
 		return
 	}
 
 	customDataPointState := customDataPointDataSourceModel{
+		Tenant:    data.Tenant,
 		Id:        types.StringValue(customDataPoint.Id),
 		Name:      types.StringValue(customDataPoint.Name),
 		DataType:  types.StringValue(customDataPoint.DataType),
@@ -107,12 +115,12 @@ func (d *customDataPointDataSource) Configure(ctx context.Context, req datasourc
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
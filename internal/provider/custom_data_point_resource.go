@@ -32,7 +32,7 @@ func NewCustomDataPointResource() resource.Resource {
 }
 
 type customDataPointResource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 type customDataPointResourceModel struct {
@@ -42,6 +42,7 @@ type customDataPointResourceModel struct {
 	ModelType   types.String `tfsdk:"model_type"`
 	Description types.String `tfsdk:"description"`
 	IsPublic    types.Bool   `tfsdk:"is_public"`
+	Tenant      types.String `tfsdk:"tenant"`
 }
 
 func (r *customDataPointResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,6 +52,7 @@ func (r *customDataPointResource) Metadata(_ context.Context, req resource.Metad
 func (r *customDataPointResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantResourceAttribute(),
 			"id": schema.StringAttribute{
 				Description: "The id of the custom data point.",
 				Computed:    true,
@@ -103,6 +105,11 @@ func (r *customDataPointResource) Schema(_ context.Context, _ resource.SchemaReq
 }
 
 func (r *customDataPointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan customDataPointResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -122,12 +129,20 @@ func (r *customDataPointResource) Create(ctx context.Context, req resource.Creat
 		customDataPointToCreate.Description = authsignal.SetValue(customDataPointDescription)
 	}
 
-	customDataPoint, _, err := r.client.CreateCustomDataPoint(customDataPointToCreate)
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	customDataPoint, _, err := client.CreateCustomDataPoint(ctx, customDataPointToCreate)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error creating custom data point",
-			"Could not create custom data point, unexpected error: "+err.Error(),
-		)
+			"Could not create custom data point",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 
@@ -149,7 +164,13 @@ func (r *customDataPointResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	customDataPoint, statusCode, err := r.client.GetCustomDataPoint(state.Id.ValueString())
+	client, diags := r.client.forTenant(state.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	customDataPoint, statusCode, err := client.GetCustomDataPoint(ctx, state.Id.ValueString())
 
 	if err != nil {
 		if statusCode == 404 {
@@ -190,6 +211,11 @@ func (r *customDataPointResource) Read(ctx context.Context, req resource.ReadReq
 }
 
 func (r *customDataPointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan customDataPointResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -202,12 +228,20 @@ func (r *customDataPointResource) Update(ctx context.Context, req resource.Updat
 		IsPublic: authsignal.SetValue(plan.IsPublic.ValueBool()),
 	}
 
-	customDataPoint, _, err := r.client.UpdateCustomDataPoint(plan.Id.ValueString(), customDataPointToUpdate)
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	customDataPoint, _, err := client.UpdateCustomDataPoint(ctx, plan.Id.ValueString(), customDataPointToUpdate)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error updating custom data point",
-			"Could not update custom data point, unexpected error: "+err.Error(),
-		)
+			"Could not update custom data point",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 
@@ -229,6 +263,11 @@ func (r *customDataPointResource) Update(ctx context.Context, req resource.Updat
 }
 
 func (r *customDataPointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state customDataPointResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -236,18 +275,27 @@ func (r *customDataPointResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	_, _, err := r.client.DeleteCustomDataPoint(state.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
+	client, diags := r.client.forTenant(state.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, statusCode, err := client.DeleteCustomDataPoint(ctx, state.Id.ValueString())
+	if err != nil && statusCode != 404 {
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error Deleting Authsignal custom data point",
-			"Could not delete custom data point, unexpected error: "+err.Error(),
-		)
+			"Could not delete custom data point",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 }
 
 func (r *customDataPointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := r.client.importTenant(ctx, req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
 func (r *customDataPointResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -255,12 +303,12 @@ func (r *customDataPointResource) Configure(_ context.Context, req resource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -162,3 +164,56 @@ func TestAccCustomDataPointResource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccCustomDataPointResourceApiErrors(t *testing.T) {
+	api := testAccRequireFakeApi(t)
+
+	config := `
+		resource "authsignal_custom_data_point" "terraform_acc_test_api_errors" {
+			name = "Terraform_Acc_Test_Api_Errors"
+			data_type = "text"
+			model_type = "action"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A conflict is reported with the API's description of it, and nothing is created
+			{
+				PreConfig: func() {
+					api.failNext(http.MethodPost, "/custom-data-points", http.StatusConflict, 1)
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile("Could not create custom data point: Conflict"),
+			},
+			// Rate limited creates are retried
+			{
+				PreConfig: func() {
+					api.failNext(http.MethodPost, "/custom-data-points", http.StatusTooManyRequests, 2)
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_custom_data_point.terraform_acc_test_api_errors", "name", "Terraform_Acc_Test_Api_Errors"),
+				),
+			},
+			// Reads that fail with a server error are retried
+			{
+				PreConfig: func() {
+					api.failNext(http.MethodGet, "/custom-data-points", http.StatusInternalServerError, 1)
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			// A custom data point that is gone is planned to be created again
+			{
+				PreConfig: func() {
+					api.failNext(http.MethodGet, "/custom-data-points", http.StatusNotFound, 1)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
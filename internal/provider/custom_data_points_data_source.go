@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &customDataPointsDataSource{}
+	_ datasource.DataSourceWithConfigure = &customDataPointsDataSource{}
+)
+
+func NewCustomDataPointsDataSource() datasource.DataSource {
+	return &customDataPointsDataSource{}
+}
+
+type customDataPointsDataSource struct {
+	client *authsignalClient
+}
+
+type customDataPointsDataSourceModel struct {
+	CustomDataPoints []customDataPointDataSourceModel `tfsdk:"custom_data_points"`
+	Tenant           types.String                     `tfsdk:"tenant"`
+}
+
+func (d *customDataPointsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_data_points"
+}
+
+func (d *customDataPointsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the custom data points on a tenant. Reading it fails until the Management API client can list them.",
+		Attributes: map[string]schema.Attribute{
+			"tenant": tenantDataSourceAttribute(),
+			"custom_data_points": schema.ListNestedAttribute{
+				Description: "The tenant's custom data points, ordered by id.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tenant": tenantListedAttribute(),
+						"id": schema.StringAttribute{
+							Description: "The id of the custom data point.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the custom data point.",
+							Computed:    true,
+						},
+						"data_type": schema.StringAttribute{
+							Description: "The data type of the custom data point. Allowed values: `text`, `number`, `boolean`, 'multiselect'.",
+							Computed:    true,
+						},
+						"model_type": schema.StringAttribute{
+							Description: "The model type of the custom data point. Allowed values: `action`, `user`.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the custom data point.",
+							Computed:    true,
+						},
+						"is_public": schema.BoolAttribute{
+							Description: "Whether the data point's value is surfaced when getting push challenges and claiming QR code challenges.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *customDataPointsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data customDataPointsDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.client.forTenant(data.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	customDataPoints, _, err := client.ListCustomDataPoints(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to List Authsignal CustomDataPoints", err.Error())
+		return
+	}
+
+	sort.SliceStable(customDataPoints, func(a, b int) bool {
+		return customDataPoints[a].Id < customDataPoints[b].Id
+	})
+
+	data.CustomDataPoints = []customDataPointDataSourceModel{}
+
+	for _, customDataPoint := range customDataPoints {
+		customDataPointState := customDataPointDataSourceModel{
+			Id:        types.StringValue(customDataPoint.Id),
+			Name:      types.StringValue(customDataPoint.Name),
+			DataType:  types.StringValue(customDataPoint.DataType),
+			ModelType: types.StringValue(customDataPoint.ModelType),
+			IsPublic:  types.BoolValue(customDataPoint.IsPublic),
+			Tenant:    data.Tenant,
+		}
+
+		if len(customDataPoint.Description) > 0 {
+			customDataPointState.Description = types.StringValue(customDataPoint.Description)
+		} else {
+			customDataPointState.Description = types.StringNull()
+		}
+
+		data.CustomDataPoints = append(data.CustomDataPoints, customDataPointState)
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *customDataPointsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring Authsignal client")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*authsignalClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,329 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ExportOptions configures Export.
+type ExportOptions struct {
+	// OutDir is the directory the configuration is written to, one file per resource type. When it
+	// is empty, the configuration is written to Stdout.
+	OutDir string
+
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Version is the provider version, as passed to New.
+	Version string
+}
+
+// exportedResource is one resource found on the tenant, along with the ID it is imported by.
+type exportedResource struct {
+	resourceType string
+	name         string
+	importId     string
+	newResource  func() resource.Resource
+}
+
+// Export reads every resource on the tenant and writes Terraform configuration for it: a resource
+// block, and an import block so that the first `terraform plan` adopts the resource rather than
+// creating it. The provider is configured from the environment, exactly as Terraform would configure
+// it with an empty provider block.
+//
+// Each resource is read through its own ImportState and Read, so the exported configuration is the
+// state Terraform would import. Rules are exported as `authsignal_rule` resources, not as
+// `authsignal_action_rules`, which would manage the same rules a second time.
+func Export(ctx context.Context, options ExportOptions) error {
+	if options.Stdout == nil {
+		options.Stdout = os.Stdout
+	}
+	if options.Stderr == nil {
+		options.Stderr = os.Stderr
+	}
+
+	client, err := exportClient(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	resources, err := exportInventory(ctx, client, options.Stderr)
+	if err != nil {
+		return err
+	}
+
+	files := map[string]*hclwrite.File{}
+	resourceTypes := []string{}
+
+	for _, exported := range resources {
+		resourceSchema, state, err := exportReadResource(ctx, client, exported, options.Stderr)
+		if err != nil {
+			return fmt.Errorf("unable to export %s.%s: %w", exported.resourceType, exported.name, err)
+		}
+
+		// The resource no longer exists, or is a singleton the tenant has not set up.
+		if state.IsNull() {
+			continue
+		}
+
+		file, ok := files[exported.resourceType]
+		if !ok {
+			file = hclwrite.NewEmptyFile()
+			files[exported.resourceType] = file
+			resourceTypes = append(resourceTypes, exported.resourceType)
+		} else {
+			file.Body().AppendNewline()
+		}
+
+		writeImportBlock(file.Body(), exported.resourceType, exported.name, exported.importId)
+		file.Body().AppendNewline()
+
+		if err := writeResourceBlock(file.Body(), exported.resourceType, exported.name, resourceSchema, state); err != nil {
+			return fmt.Errorf("unable to export %s.%s: %w", exported.resourceType, exported.name, err)
+		}
+	}
+
+	if options.OutDir == "" {
+		for i, resourceType := range resourceTypes {
+			if i > 0 {
+				fmt.Fprintln(options.Stdout)
+			}
+			if _, err := options.Stdout.Write(hclwrite.Format(files[resourceType].Bytes())); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(options.OutDir, 0o755); err != nil {
+		return err
+	}
+
+	for _, resourceType := range resourceTypes {
+		filename := filepath.Join(options.OutDir, resourceType+".tf")
+
+		// O_EXCL so that an export never overwrites configuration that is already there.
+		file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return err
+		}
+
+		_, err = file.Write(hclwrite.Format(files[resourceType].Bytes()))
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(options.Stderr, "Wrote %s\n", filename)
+	}
+
+	return nil
+}
+
+// exportClient configures the provider with every attribute null, so it reads its settings from the
+// environment.
+func exportClient(ctx context.Context, options ExportOptions) (*authsignalClient, error) {
+	p := New(options.Version)()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		return nil, diagnosticsError(schemaResp.Diagnostics)
+	}
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	configureReq := provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, attributes),
+		},
+	}
+
+	var configureResp provider.ConfigureResponse
+	p.Configure(ctx, configureReq, &configureResp)
+	writeWarnings(options.Stderr, configureResp.Diagnostics)
+	if configureResp.Diagnostics.HasError() {
+		return nil, diagnosticsError(configureResp.Diagnostics)
+	}
+
+	client, ok := configureResp.ResourceData.(*authsignalClient)
+	if !ok {
+		return nil, fmt.Errorf("expected *authsignalClient, got: %T", configureResp.ResourceData)
+	}
+
+	return client, nil
+}
+
+// exportInventory lists every resource on the tenant, in the order it is written out. Resource
+// types the Management API client cannot list yet are left out, with a note on stderr.
+func exportInventory(ctx context.Context, client *authsignalClient, stderr io.Writer) ([]exportedResource, error) {
+	resources := []exportedResource{}
+	names := map[string]map[string]bool{}
+
+	add := func(resourceType string, identifier string, importId string, newResource func() resource.Resource) {
+		if names[resourceType] == nil {
+			names[resourceType] = map[string]bool{}
+		}
+
+		name := exportResourceName(identifier)
+		for i := 2; names[resourceType][name]; i++ {
+			name = fmt.Sprintf("%s_%d", exportResourceName(identifier), i)
+		}
+		names[resourceType][name] = true
+
+		resources = append(resources, exportedResource{
+			resourceType: resourceType,
+			name:         name,
+			importId:     importId,
+			newResource:  newResource,
+		})
+	}
+
+	add("authsignal_theme", "theme", "", NewThemeResource)
+	add("authsignal_pre_built_ui_settings", "pre_built_ui_settings", "", NewPreBuiltUiSettingsResource)
+	add("authsignal_message_overrides", "message_overrides", "", NewMessageOverridesResource)
+
+	customDataPoints, _, err := client.ListCustomDataPoints(ctx)
+	if isListUnsupported(err) {
+		fmt.Fprintf(stderr, "Skipped custom data points: %s\n", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to list custom data points: %w", err)
+	}
+	sort.SliceStable(customDataPoints, func(a, b int) bool {
+		return customDataPoints[a].Name < customDataPoints[b].Name
+	})
+	for _, customDataPoint := range customDataPoints {
+		add("authsignal_custom_data_point", customDataPoint.Name, customDataPoint.Id, NewCustomDataPointResource)
+	}
+
+	valueLists, _, err := client.ListValueLists(ctx)
+	if isListUnsupported(err) {
+		fmt.Fprintf(stderr, "Skipped value lists: %s\n", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to list value lists: %w", err)
+	}
+	sort.SliceStable(valueLists, func(a, b int) bool {
+		return valueLists[a].Alias < valueLists[b].Alias
+	})
+	for _, valueList := range valueLists {
+		add("authsignal_value_list", valueList.Alias, valueList.Alias, NewValueListResource)
+	}
+
+	actionConfigurations, _, err := client.ListActionConfigurations(ctx)
+	if isListUnsupported(err) {
+		fmt.Fprintf(stderr, "Skipped action configurations: %s\n", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to list action configurations: %w", err)
+	}
+	sort.SliceStable(actionConfigurations, func(a, b int) bool {
+		return actionConfigurations[a].ActionCode < actionConfigurations[b].ActionCode
+	})
+	for _, actionConfiguration := range actionConfigurations {
+		add("authsignal_action_configuration", actionConfiguration.ActionCode, actionConfiguration.ActionCode, NewActionConfigurationResource)
+	}
+
+	for _, actionConfiguration := range actionConfigurations {
+		rules, statusCode, err := client.ListRules(ctx, actionConfiguration.ActionCode)
+		if isListUnsupported(err) {
+			fmt.Fprintf(stderr, "Skipped rules: %s\n", err)
+			break
+		}
+		if statusCode == 404 {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the rules of action %q: %w", actionConfiguration.ActionCode, err)
+		}
+
+		sort.SliceStable(rules, func(a, b int) bool {
+			return rules[a].Priority < rules[b].Priority
+		})
+		for _, rule := range rules {
+			add("authsignal_rule", actionConfiguration.ActionCode+"_"+rule.Name, actionConfiguration.ActionCode+"/"+rule.RuleId, NewRuleResource)
+		}
+	}
+
+	return resources, nil
+}
+
+// exportReadResource imports a resource and reads it, the same way `terraform import` does, and
+// returns its schema and state. The state is null when the resource does not exist.
+func exportReadResource(ctx context.Context, client *authsignalClient, exported exportedResource, stderr io.Writer) (resourceSchema schema.Schema, state tftypes.Value, err error) {
+	r := exported.newResource()
+
+	if configurable, ok := r.(resource.ResourceWithConfigure); ok {
+		var configureResp resource.ConfigureResponse
+		configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &configureResp)
+		if configureResp.Diagnostics.HasError() {
+			return resourceSchema, state, diagnosticsError(configureResp.Diagnostics)
+		}
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		return resourceSchema, state, diagnosticsError(schemaResp.Diagnostics)
+	}
+	resourceSchema = schemaResp.Schema
+
+	importer, ok := r.(resource.ResourceWithImportState)
+	if !ok {
+		return resourceSchema, state, errors.New("resource does not support import")
+	}
+
+	importResp := resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: resourceSchema,
+			Raw:    tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil),
+		},
+	}
+	importer.ImportState(ctx, resource.ImportStateRequest{ID: exported.importId}, &importResp)
+	writeWarnings(stderr, importResp.Diagnostics)
+	if importResp.Diagnostics.HasError() {
+		return resourceSchema, state, diagnosticsError(importResp.Diagnostics)
+	}
+
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	writeWarnings(stderr, readResp.Diagnostics)
+	if readResp.Diagnostics.HasError() {
+		return resourceSchema, state, diagnosticsError(readResp.Diagnostics)
+	}
+
+	return resourceSchema, readResp.State.Raw, nil
+}
+
+func diagnosticsError(diags diag.Diagnostics) error {
+	messages := []string{}
+	for _, d := range diags.Errors() {
+		messages = append(messages, d.Summary()+": "+d.Detail())
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
+
+func writeWarnings(w io.Writer, diags diag.Diagnostics) {
+	for _, d := range diags.Warnings() {
+		fmt.Fprintf(w, "Warning: %s: %s\n", d.Summary(), d.Detail())
+	}
+}
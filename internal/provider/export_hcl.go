@@ -0,0 +1,323 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// exportJsonAttributes are string attributes holding JSON. They are written as jsonencode() of the
+// equivalent HCL, so that the exported configuration can be read and edited.
+var exportJsonAttributes = map[string]bool{
+	"conditions": true,
+}
+
+var exportInvalidNameCharacters = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// exportResourceName turns an identifier from the API into a Terraform resource name.
+func exportResourceName(identifier string) string {
+	name := strings.Trim(exportInvalidNameCharacters.ReplaceAllString(strings.ToLower(identifier), "_"), "_-")
+
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+
+	return name
+}
+
+// writeImportBlock writes a Terraform 1.5 `import` block, so that `terraform plan` imports the
+// resource instead of creating it.
+func writeImportBlock(body *hclwrite.Body, resourceType string, name string, id string) {
+	block := body.AppendNewBlock("import", nil)
+	block.Body().SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: name},
+	})
+	block.Body().SetAttributeValue("id", cty.StringVal(id))
+}
+
+// writeResourceBlock writes a resource block holding the configurable attributes of a resource's
+// state. Computed-only, deprecated and null attributes are left out.
+func writeResourceBlock(body *hclwrite.Body, resourceType string, name string, resourceSchema schema.Schema, state tftypes.Value) error {
+	block := body.AppendNewBlock("resource", []string{resourceType, name})
+	return writeExportObject(block.Body(), resourceSchema.Attributes, resourceSchema.Blocks, state)
+}
+
+func writeExportObject(body *hclwrite.Body, attributes map[string]schema.Attribute, blocks map[string]schema.Block, value tftypes.Value) error {
+	var values map[string]tftypes.Value
+	if err := value.As(&values); err != nil {
+		return err
+	}
+
+	for _, name := range sortedKeys(attributes) {
+		attribute := attributes[name]
+		attributeValue := values[name]
+
+		if !isExportedAttribute(attribute) || attributeValue.IsNull() || !attributeValue.IsKnown() {
+			continue
+		}
+
+		if exportJsonAttributes[name] && attributeValue.Type().Equal(tftypes.String) {
+			var jsonString string
+			if err := attributeValue.As(&jsonString); err != nil {
+				return err
+			}
+
+			tokens, err := jsonencodeTokens(jsonString)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+
+			body.SetAttributeRaw(name, tokens)
+			continue
+		}
+
+		exported, err := exportAttributeValue(attribute, attributeValue)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		body.SetAttributeValue(name, exported)
+	}
+
+	for _, name := range sortedKeys(blocks) {
+		blockValue := values[name]
+		if blockValue.IsNull() || !blockValue.IsKnown() {
+			continue
+		}
+
+		switch block := blocks[name].(type) {
+		case schema.ListNestedBlock:
+			if err := writeExportBlocks(body, name, block.NestedObject, blockValue); err != nil {
+				return err
+			}
+		case schema.SetNestedBlock:
+			if err := writeExportBlocks(body, name, block.NestedObject, blockValue); err != nil {
+				return err
+			}
+		case schema.SingleNestedBlock:
+			nested := body.AppendNewBlock(name, nil)
+			if err := writeExportObject(nested.Body(), block.Attributes, block.Blocks, blockValue); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeExportBlocks(body *hclwrite.Body, name string, nestedObject schema.NestedBlockObject, value tftypes.Value) error {
+	var elements []tftypes.Value
+	if err := value.As(&elements); err != nil {
+		return err
+	}
+
+	for _, element := range elements {
+		nested := body.AppendNewBlock(name, nil)
+		if err := writeExportObject(nested.Body(), nestedObject.Attributes, nestedObject.Blocks, element); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func isExportedAttribute(attribute schema.Attribute) bool {
+	return (attribute.IsRequired() || attribute.IsOptional()) && attribute.GetDeprecationMessage() == ""
+}
+
+// exportAttributeValue converts an attribute's value to cty, dropping the attributes of nested
+// objects that are not exported.
+func exportAttributeValue(attribute schema.Attribute, value tftypes.Value) (cty.Value, error) {
+	switch attribute := attribute.(type) {
+	case schema.SingleNestedAttribute:
+		return exportNestedObject(attribute.Attributes, value)
+	case schema.ListNestedAttribute:
+		return exportNestedObjects(attribute.NestedObject.Attributes, value)
+	case schema.SetNestedAttribute:
+		return exportNestedObjects(attribute.NestedObject.Attributes, value)
+	case schema.MapNestedAttribute:
+		var elements map[string]tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return cty.NilVal, err
+		}
+
+		exported := map[string]cty.Value{}
+		for key, element := range elements {
+			exportedElement, err := exportNestedObject(attribute.NestedObject.Attributes, element)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			exported[key] = exportedElement
+		}
+
+		return cty.ObjectVal(exported), nil
+	}
+
+	return exportPlainValue(value)
+}
+
+func exportNestedObject(attributes map[string]schema.Attribute, value tftypes.Value) (cty.Value, error) {
+	if value.IsNull() {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+
+	var values map[string]tftypes.Value
+	if err := value.As(&values); err != nil {
+		return cty.NilVal, err
+	}
+
+	exported := map[string]cty.Value{}
+	for name, attribute := range attributes {
+		if !isExportedAttribute(attribute) || values[name].IsNull() || !values[name].IsKnown() {
+			continue
+		}
+
+		exportedAttribute, err := exportAttributeValue(attribute, values[name])
+		if err != nil {
+			return cty.NilVal, fmt.Errorf("%s: %w", name, err)
+		}
+		exported[name] = exportedAttribute
+	}
+
+	return cty.ObjectVal(exported), nil
+}
+
+func exportNestedObjects(attributes map[string]schema.Attribute, value tftypes.Value) (cty.Value, error) {
+	var elements []tftypes.Value
+	if err := value.As(&elements); err != nil {
+		return cty.NilVal, err
+	}
+
+	exported := []cty.Value{}
+	for _, element := range elements {
+		exportedElement, err := exportNestedObject(attributes, element)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		exported = append(exported, exportedElement)
+	}
+
+	return cty.TupleVal(exported), nil
+}
+
+// exportPlainValue converts a value without nested attributes to cty. Collections become tuples and
+// objects, which is how they are written in HCL regardless of their element type.
+func exportPlainValue(value tftypes.Value) (cty.Value, error) {
+	if value.IsNull() {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+
+	switch value.Type().(type) {
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return cty.NilVal, err
+		}
+
+		exported := []cty.Value{}
+		for _, element := range elements {
+			exportedElement, err := exportPlainValue(element)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			exported = append(exported, exportedElement)
+		}
+
+		return cty.TupleVal(exported), nil
+	case tftypes.Map, tftypes.Object:
+		var elements map[string]tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return cty.NilVal, err
+		}
+
+		exported := map[string]cty.Value{}
+		for key, element := range elements {
+			exportedElement, err := exportPlainValue(element)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			exported[key] = exportedElement
+		}
+
+		return cty.ObjectVal(exported), nil
+	}
+
+	switch {
+	case value.Type().Equal(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return cty.StringVal(s), err
+	case value.Type().Equal(tftypes.Number):
+		n := new(big.Float)
+		err := value.As(&n)
+		return cty.NumberVal(n), err
+	case value.Type().Equal(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return cty.BoolVal(b), err
+	}
+
+	return cty.NilVal, fmt.Errorf("unsupported type %s", value.Type())
+}
+
+// jsonencodeTokens renders a JSON document as a call to jsonencode() with the equivalent HCL.
+func jsonencodeTokens(jsonString string) (hclwrite.Tokens, error) {
+	decoder := json.NewDecoder(strings.NewReader(jsonString))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	value, err := jsonToCty(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return hclwrite.TokensForFunctionCall("jsonencode", hclwrite.TokensForValue(value)), nil
+}
+
+func jsonToCty(document any) (cty.Value, error) {
+	switch document := document.(type) {
+	case nil:
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	case bool:
+		return cty.BoolVal(document), nil
+	case string:
+		return cty.StringVal(document), nil
+	case json.Number:
+		return cty.ParseNumberVal(document.String())
+	case []any:
+		elements := []cty.Value{}
+		for _, element := range document {
+			value, err := jsonToCty(element)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			elements = append(elements, value)
+		}
+		return cty.TupleVal(elements), nil
+	case map[string]any:
+		attributes := map[string]cty.Value{}
+		for key, element := range document {
+			value, err := jsonToCty(element)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			attributes[key] = value
+		}
+		return cty.ObjectVal(attributes), nil
+	}
+
+	return cty.NilVal, fmt.Errorf("unsupported JSON value %T", document)
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestExportResourceName(t *testing.T) {
+	testCases := map[string]string{
+		"login_Block Anonymous": "login_block_anonymous",
+		"hello-world-strings":   "hello-world-strings",
+		"2fa":                   "_2fa",
+		"!!!":                   "_",
+	}
+
+	for identifier, expected := range testCases {
+		if got := exportResourceName(identifier); got != expected {
+			t.Fatalf("bad name for %q. expected: %v. got : %v", identifier, expected, got)
+		}
+	}
+}
+
+func TestWriteResourceBlock(t *testing.T) {
+	ctx := context.Background()
+
+	resourceSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name":        schema.StringAttribute{Required: true},
+			"priority":    schema.Int64Attribute{Required: true},
+			"rule_id":     schema.StringAttribute{Computed: true},
+			"description": schema.StringAttribute{Optional: true},
+			"verification_methods": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"conditions": schema.StringAttribute{Optional: true},
+			"borders": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"card_border_width":  schema.Int64Attribute{Optional: true},
+					"input_border_width": schema.Int64Attribute{Optional: true},
+					"updated_at":         schema.StringAttribute{Computed: true},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"rule": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{Required: true},
+					},
+				},
+			},
+		},
+	}
+
+	objectType := resourceSchema.Type().TerraformType(ctx).(tftypes.Object)
+	bordersType := objectType.AttributeTypes["borders"]
+	ruleType := objectType.AttributeTypes["rule"].(tftypes.List).ElementType
+
+	state := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name":                 tftypes.NewValue(tftypes.String, "block-anonymous"),
+		"priority":             tftypes.NewValue(tftypes.Number, big.NewFloat(2)),
+		"rule_id":              tftypes.NewValue(tftypes.String, "a2d9670f"),
+		"description":          tftypes.NewValue(tftypes.String, nil),
+		"verification_methods": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "EMAIL_OTP")}),
+		"conditions":           tftypes.NewValue(tftypes.String, `{"and":[{"==":[{"var":"ip.isAnonymous"},true]}]}`),
+		"borders": tftypes.NewValue(bordersType, map[string]tftypes.Value{
+			"card_border_width":  tftypes.NewValue(tftypes.Number, big.NewFloat(1)),
+			"input_border_width": tftypes.NewValue(tftypes.Number, nil),
+			"updated_at":         tftypes.NewValue(tftypes.String, "yesterday"),
+		}),
+		"rule": tftypes.NewValue(objectType.AttributeTypes["rule"], []tftypes.Value{
+			tftypes.NewValue(ruleType, map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "first"),
+			}),
+		}),
+	})
+
+	file := hclwrite.NewEmptyFile()
+	writeImportBlock(file.Body(), "authsignal_rule", "login_block-anonymous", "login/a2d9670f")
+	file.Body().AppendNewline()
+	if err := writeResourceBlock(file.Body(), "authsignal_rule", "login_block-anonymous", resourceSchema, state); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `import {
+  to = authsignal_rule.login_block-anonymous
+  id = "login/a2d9670f"
+}
+
+resource "authsignal_rule" "login_block-anonymous" {
+  borders = {
+    card_border_width = 1
+  }
+  conditions = jsonencode({
+    and = [{
+      "==" = [{
+        var = "ip.isAnonymous"
+      }, true]
+    }]
+  })
+  name                 = "block-anonymous"
+  priority             = 2
+  verification_methods = ["EMAIL_OTP"]
+  rule {
+    name = "first"
+  }
+}
+`
+
+	if got := string(hclwrite.Format(file.Bytes())); got != expected {
+		t.Fatalf("bad configuration. expected:\n%v\ngot:\n%v", expected, got)
+	}
+}
+
+func TestJsonencodeTokensRoundTrip(t *testing.T) {
+	tokens, err := jsonencodeTokens(`{"en":{"defaultTemplate":"Hi ${name}","count":1.5,"empty":null}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `jsonencode({
+  en = {
+    count           = 1.5
+    defaultTemplate = "Hi $${name}"
+    empty           = null
+  }
+})`
+
+	if got := string(hclwrite.Format(tokens.Bytes())); got != expected {
+		t.Fatalf("bad tokens. expected:\n%v\ngot:\n%v", expected, got)
+	}
+}
@@ -0,0 +1,1012 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/authsignal/authsignal-management-go/v6"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// The fake Management API is an in-memory stand-in for a tenant, which the acceptance tests run
+// against unless AUTHSIGNAL_TEST_LIVE is set. It serves rules, action configurations, value lists,
+// custom data points, the theme, tenant settings and message overrides with the catalog, under
+// /v1/management like the real API, and is seeded with the objects the data source tests read.
+//
+// The fields it accepts and returns come from the Management API client's request and response types,
+// so that a test cannot pass on a field the client would not send or could not read. It has no list
+// endpoints, as the client has no list calls. Anything the types do not say, such as the error
+// responses' codes and how each endpoint validates, is a guess, which running the tests against a real
+// tenant checks. So its errors have only the description of the error shape apiError reads, and no
+// error code.
+
+const (
+	fakeApiTenantId  = "00000000-0000-4000-8000-000000000000"
+	fakeApiSecret    = "fake-api-secret"
+	fakeApiBasePath  = "/v1/management"
+	fakeApiIdPattern = "00000000-0000-4000-8000-%012d"
+)
+
+type fakeApi struct {
+	server *httptest.Server
+
+	mu sync.Mutex
+
+	actionConfigurations map[string]map[string]any
+	// rules are by action code, then rule ID.
+	rules            map[string]map[string]map[string]any
+	valueLists       map[string]map[string]any
+	customDataPoints map[string]map[string]any
+	theme            map[string]any
+	tenant           map[string]any
+	messageOverrides map[string]any
+	catalog          map[string]any
+
+	errors   []*fakeApiError
+	requests []string
+	nextId   int
+
+	// priorityOverlaps records every time a rule was written while another rule on the same action had
+	// the same priority.
+	priorityOverlaps []string
+}
+
+// fakeApiError is an error injected with failNext.
+type fakeApiError struct {
+	method     string
+	path       string
+	statusCode int
+	skip       int
+	remaining  int
+}
+
+func newFakeApi() *fakeApi {
+	f := &fakeApi{
+		actionConfigurations: map[string]map[string]any{},
+		rules:                map[string]map[string]map[string]any{},
+		valueLists:           map[string]map[string]any{},
+		customDataPoints:     map[string]map[string]any{},
+		tenant:               map[string]any{"hideSuccessScreenOnEnrollment": false},
+		messageOverrides:     map[string]any{},
+	}
+
+	f.seed()
+	f.server = httptest.NewServer(f)
+
+	return f
+}
+
+// url returns the host to configure the provider with.
+func (f *fakeApi) url() string {
+	return f.server.URL + fakeApiBasePath
+}
+
+func (f *fakeApi) close() {
+	f.server.Close()
+}
+
+// failNext makes the next times requests to endpoint, or to anything under it, fail with statusCode
+// before they reach the tenant. An empty method matches every method. A 429 asks to be retried
+// straight away.
+func (f *fakeApi) failNext(method string, endpoint string, statusCode int, times int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.errors = append(f.errors, &fakeApiError{method: method, path: endpoint, statusCode: statusCode, remaining: times})
+}
+
+// failAfter lets skip requests to endpoint through, then makes the one after fail with statusCode.
+func (f *fakeApi) failAfter(method string, endpoint string, statusCode int, skip int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.errors = append(f.errors, &fakeApiError{method: method, path: endpoint, statusCode: statusCode, skip: skip, remaining: 1})
+}
+
+// overlappingPriorities returns the rule priorities that were briefly held by two rules at once, as
+// the action code and the priority.
+func (f *fakeApi) overlappingPriorities() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string{}, f.priorityOverlaps...)
+}
+
+// sent returns the requests received so far, as the method and the path under /v1/management, such
+// as `GET /theme`.
+func (f *fakeApi) sent() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string{}, f.requests...)
+}
+
+func (f *fakeApi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Request-Id", fmt.Sprintf("fake-request-%d", len(f.requests)+1))
+
+	endpoint, ok := strings.CutPrefix(r.URL.Path, fakeApiBasePath)
+	f.requests = append(f.requests, r.Method+" "+endpoint)
+	if !ok {
+		writeFakeApiResponse(w, http.StatusNotFound, fakeApiErrorBody("Not found"))
+		return
+	}
+
+	for _, injected := range f.errors {
+		if injected.remaining > 0 && (injected.method == "" || injected.method == r.Method) && isPathWithin(endpoint, injected.path) {
+			if injected.skip > 0 {
+				injected.skip--
+				continue
+			}
+			injected.remaining--
+			if injected.statusCode == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "0")
+			}
+			writeFakeApiResponse(w, injected.statusCode, fakeApiErrorBody(http.StatusText(injected.statusCode)))
+			return
+		}
+	}
+
+	if apiSecret, _, ok := r.BasicAuth(); !ok || apiSecret != fakeApiSecret {
+		writeFakeApiResponse(w, http.StatusUnauthorized, fakeApiErrorBody("The API secret is not valid"))
+		return
+	}
+
+	body := map[string]any{}
+	if r.Method != http.MethodGet && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeFakeApiResponse(w, http.StatusBadRequest, fakeApiErrorBody("The body is not a JSON object"))
+			return
+		}
+	}
+
+	segments := strings.Split(strings.Trim(endpoint, "/"), "/")
+	requestShape, responseShape := fakeApiShapes(segments)
+
+	if err := requestShape.check(body, ""); err != nil {
+		writeFakeApiResponse(w, http.StatusBadRequest, fakeApiErrorBody(err.Error()))
+		return
+	}
+
+	statusCode, response := f.route(r.Method, segments, body)
+	if statusCode >= 200 && statusCode <= 299 {
+		response = responseShape.filter(response)
+	}
+	writeFakeApiResponse(w, statusCode, response)
+}
+
+// fakeApiShape is the fields of a JSON object, by their lower case name, as encoding/json matches them
+// without regard to case. A nil shape is any value, such as a map or a condition.
+type fakeApiShape struct {
+	fields map[string]*fakeApiShape
+}
+
+// fakeApiShapeOf returns the shape a value of type t has in JSON.
+func fakeApiShapeOf(t reflect.Type) *fakeApiShape {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return fakeApiShapeOf(t.Elem())
+	case reflect.Map:
+		// NullableJsonInput holds its value in a map keyed by whether it is set.
+		if t.Key().Kind() == reflect.Bool {
+			return fakeApiShapeOf(t.Elem())
+		}
+		return nil
+	case reflect.Struct:
+		shape := &fakeApiShape{fields: map[string]*fakeApiShape{}}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			shape.fields[strings.ToLower(name)] = fakeApiShapeOf(field.Type)
+		}
+		return shape
+	}
+
+	return nil
+}
+
+// fakeApiShapes returns the shapes of the request and response bodies of an endpoint, from the types
+// the Management API client sends and reads.
+func fakeApiShapes(segments []string) (request *fakeApiShape, response *fakeApiShape) {
+	var requestType, responseType any
+
+	switch {
+	case segments[0] == "actions" && len(segments) <= 2:
+		requestType, responseType = authsignal.ActionConfiguration{}, authsignal.ActionConfigurationResponse{}
+	case segments[0] == "actions":
+		requestType, responseType = authsignal.Rule{}, authsignal.RuleResponse{}
+	case segments[0] == "value-lists":
+		requestType, responseType = authsignal.ValueList{}, authsignal.ValueListResponse{}
+	case segments[0] == "custom-data-points":
+		requestType, responseType = authsignal.CustomDataPoint{}, authsignal.CustomDataPointResponse{}
+	case segments[0] == "theme":
+		requestType, responseType = authsignal.Theme{}, authsignal.ThemeResponse{}
+	case segments[0] == "tenant":
+		requestType, responseType = authsignal.TenantSettings{}, authsignal.TenantResponse{}
+	case segments[0] == "message-overrides" && len(segments) == 2:
+		responseType = authsignal.MessageOverridesCatalog{}
+	case segments[0] == "message-overrides":
+		requestType, responseType = authsignal.MessageOverridesBody{}, authsignal.MessageOverridesBody{}
+	default:
+		return nil, nil
+	}
+
+	// An endpoint that takes no body takes no fields either.
+	request = &fakeApiShape{fields: map[string]*fakeApiShape{}}
+	if requestType != nil {
+		request = fakeApiShapeOf(reflect.TypeOf(requestType))
+	}
+
+	return request, fakeApiShapeOf(reflect.TypeOf(responseType))
+}
+
+// check reports the first field of value, at the path at, that the shape does not have.
+func (s *fakeApiShape) check(value any, at string) error {
+	if s == nil {
+		return nil
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(value) {
+			field, ok := s.fields[strings.ToLower(key)]
+			if !ok {
+				return fmt.Errorf("Unknown field %q", strings.TrimPrefix(at+"."+key, "."))
+			}
+			if err := field.check(value[key], at+"."+key); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, element := range value {
+			if err := s.check(element, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// filter returns value with only the fields the shape has.
+func (s *fakeApiShape) filter(value any) any {
+	if s == nil {
+		return value
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		filtered := map[string]any{}
+		for key, fieldValue := range value {
+			if field, ok := s.fields[strings.ToLower(key)]; ok {
+				filtered[key] = field.filter(fieldValue)
+			}
+		}
+		return filtered
+	case []any:
+		filtered := make([]any, 0, len(value))
+		for _, element := range value {
+			filtered = append(filtered, s.filter(element))
+		}
+		return filtered
+	}
+
+	return value
+}
+
+func (f *fakeApi) route(method string, segments []string, body map[string]any) (int, any) {
+	write := method == http.MethodPatch || method == http.MethodPut || method == http.MethodPost
+
+	switch {
+	case len(segments) == 1 && segments[0] == "actions":
+		if method == http.MethodPost {
+			return f.createActionConfiguration(body)
+		}
+	case len(segments) == 2 && segments[0] == "actions":
+		switch {
+		case method == http.MethodGet:
+			return fakeApiFind(f.actionConfigurations, segments[1], "Action configuration")
+		case write:
+			return f.updateActionConfiguration(segments[1], body)
+		case method == http.MethodDelete:
+			delete(f.rules, segments[1])
+			return fakeApiDelete(f.actionConfigurations, segments[1], "Action configuration")
+		}
+	case len(segments) == 3 && segments[0] == "actions" && segments[2] == "rules":
+		if _, ok := f.actionConfigurations[segments[1]]; !ok {
+			return http.StatusNotFound, fakeApiErrorBody("Action configuration not found")
+		}
+		if method == http.MethodPost {
+			return f.createRule(segments[1], body)
+		}
+	case len(segments) == 4 && segments[0] == "actions" && segments[2] == "rules":
+		rules := f.rules[segments[1]]
+		switch {
+		case method == http.MethodGet:
+			return fakeApiFind(rules, segments[3], "Rule")
+		case write:
+			statusCode, response := fakeApiUpdate(rules, segments[3], "Rule", body, "ruleId", "actionCode", "tenantId")
+			f.recordPriorityOverlaps(segments[1])
+			return statusCode, response
+		case method == http.MethodDelete:
+			return fakeApiDelete(rules, segments[3], "Rule")
+		}
+	case len(segments) == 1 && segments[0] == "value-lists":
+		if method == http.MethodPost {
+			return f.createValueList(body)
+		}
+	case len(segments) == 2 && segments[0] == "value-lists":
+		switch {
+		case method == http.MethodGet:
+			return fakeApiFind(f.valueLists, segments[1], "Value list")
+		case write:
+			return f.updateValueList(segments[1], body)
+		case method == http.MethodDelete:
+			return fakeApiDelete(f.valueLists, segments[1], "Value list")
+		}
+	case len(segments) == 1 && segments[0] == "custom-data-points":
+		if method == http.MethodPost {
+			return f.createCustomDataPoint(body)
+		}
+	case len(segments) == 2 && segments[0] == "custom-data-points":
+		switch {
+		case method == http.MethodGet:
+			return fakeApiFind(f.customDataPoints, segments[1], "Custom data point")
+		case write:
+			return fakeApiUpdate(f.customDataPoints, segments[1], "Custom data point", body, "id")
+		case method == http.MethodDelete:
+			return fakeApiDelete(f.customDataPoints, segments[1], "Custom data point")
+		}
+	case len(segments) == 1 && segments[0] == "theme":
+		switch {
+		case method == http.MethodGet:
+			return http.StatusOK, f.theme
+		case write:
+			// Theme updates are partial all the way down, so an object only changes the values it has.
+			mergeFakeApiObject(f.theme, body, true)
+			return http.StatusOK, f.theme
+		}
+	case len(segments) == 1 && segments[0] == "tenant":
+		switch {
+		case method == http.MethodGet:
+			return http.StatusOK, f.tenant
+		case write:
+			mergeFakeApiObject(f.tenant, body, false)
+			return http.StatusOK, f.tenant
+		}
+	case len(segments) == 1 && segments[0] == "message-overrides":
+		switch {
+		case method == http.MethodGet:
+			return http.StatusOK, map[string]any{"messageOverrides": f.messageOverrides}
+		case write:
+			return f.updateMessageOverrides(body)
+		}
+	case len(segments) == 2 && segments[0] == "message-overrides" && segments[1] == "catalog":
+		if method == http.MethodGet {
+			return http.StatusOK, f.catalog
+		}
+	default:
+		return http.StatusNotFound, fakeApiErrorBody("Not found")
+	}
+
+	return http.StatusMethodNotAllowed, fakeApiErrorBody("Method not allowed")
+}
+
+func (f *fakeApi) createActionConfiguration(body map[string]any) (int, any) {
+	if response, ok := fakeApiRequire(body, "actionCode"); !ok {
+		return http.StatusBadRequest, response
+	}
+
+	actionCode, _ := body["actionCode"].(string)
+	if _, ok := f.actionConfigurations[actionCode]; ok {
+		return http.StatusConflict, fakeApiErrorBody(fmt.Sprintf("An action configuration for %q already exists", actionCode))
+	}
+
+	actionConfiguration := map[string]any{"tenantId": fakeApiTenantId, "defaultUserActionResult": "CHALLENGE"}
+	mergeFakeApiObject(actionConfiguration, body, false)
+	f.actionConfigurations[actionCode] = actionConfiguration
+
+	return http.StatusOK, actionConfiguration
+}
+
+func (f *fakeApi) updateActionConfiguration(actionCode string, body map[string]any) (int, any) {
+	return fakeApiUpdate(f.actionConfigurations, actionCode, "Action configuration", body, "actionCode", "tenantId")
+}
+
+func (f *fakeApi) createRule(actionCode string, body map[string]any) (int, any) {
+	if response, ok := fakeApiRequire(body, "name"); !ok {
+		return http.StatusBadRequest, response
+	}
+
+	rule := map[string]any{"ruleId": f.newId(), "actionCode": actionCode, "tenantId": fakeApiTenantId}
+	mergeFakeApiObject(rule, body, false)
+
+	if f.rules[actionCode] == nil {
+		f.rules[actionCode] = map[string]map[string]any{}
+	}
+	f.rules[actionCode][rule["ruleId"].(string)] = rule
+	f.recordPriorityOverlaps(actionCode)
+
+	return http.StatusOK, rule
+}
+
+func (f *fakeApi) recordPriorityOverlaps(actionCode string) {
+	seen := map[string]bool{}
+	for _, rule := range f.rules[actionCode] {
+		priority := fmt.Sprint(rule["priority"])
+		if seen[priority] {
+			f.priorityOverlaps = append(f.priorityOverlaps, actionCode+" "+priority)
+		}
+		seen[priority] = true
+	}
+}
+
+func (f *fakeApi) createValueList(body map[string]any) (int, any) {
+	if response, ok := fakeApiRequire(body, "name", "itemType"); !ok {
+		return http.StatusBadRequest, response
+	}
+
+	alias := fakeApiAlias(body["name"].(string))
+	if _, ok := f.valueLists[alias]; ok {
+		return http.StatusConflict, fakeApiErrorBody(fmt.Sprintf("A value list with the alias %q already exists", alias))
+	}
+
+	valueList := map[string]any{"alias": alias, "isActive": true, "valueListItems": []any{}}
+	mergeFakeApiObject(valueList, body, false)
+	f.valueLists[alias] = valueList
+
+	return http.StatusOK, valueList
+}
+
+// updateValueList updates a value list. Like the real API, renaming a value list changes its alias.
+func (f *fakeApi) updateValueList(alias string, body map[string]any) (int, any) {
+	valueList, ok := f.valueLists[alias]
+	if !ok {
+		return http.StatusNotFound, fakeApiErrorBody("Value list not found")
+	}
+
+	if name, ok := body["name"].(string); ok && fakeApiAlias(name) != alias {
+		newAlias := fakeApiAlias(name)
+		if _, ok := f.valueLists[newAlias]; ok {
+			return http.StatusConflict, fakeApiErrorBody(fmt.Sprintf("A value list with the alias %q already exists", newAlias))
+		}
+
+		delete(f.valueLists, alias)
+		valueList["alias"] = newAlias
+		f.valueLists[newAlias] = valueList
+	}
+
+	delete(body, "alias")
+	mergeFakeApiObject(valueList, body, false)
+
+	return http.StatusOK, valueList
+}
+
+func (f *fakeApi) createCustomDataPoint(body map[string]any) (int, any) {
+	if response, ok := fakeApiRequire(body, "name", "dataType", "modelType"); !ok {
+		return http.StatusBadRequest, response
+	}
+
+	for _, existing := range f.customDataPoints {
+		if existing["name"] == body["name"] && existing["modelType"] == body["modelType"] {
+			return http.StatusConflict, fakeApiErrorBody(fmt.Sprintf("A custom data point named %q already exists", body["name"]))
+		}
+	}
+
+	customDataPoint := map[string]any{"id": f.newId(), "isPublic": false}
+	mergeFakeApiObject(customDataPoint, body, false)
+	f.customDataPoints[customDataPoint["id"].(string)] = customDataPoint
+
+	return http.StatusOK, customDataPoint
+}
+
+// updateMessageOverrides replaces the tenant's message overrides, which must all be points of the
+// catalog, within their maximum length.
+func (f *fakeApi) updateMessageOverrides(body map[string]any) (int, any) {
+	overrides, _ := body["messageOverrides"].(map[string]any)
+
+	points := map[string]float64{}
+	for _, point := range f.catalog["points"].([]any) {
+		point := point.(map[string]any)
+		points[point["publicId"].(string)] = point["maxLength"].(float64)
+	}
+
+	fieldErrors := []any{}
+	for _, locale := range sortedKeys(overrides) {
+		messages, _ := overrides[locale].(map[string]any)
+		for _, publicId := range sortedKeys(messages) {
+			maxLength, ok := points[publicId]
+			message, _ := messages[publicId].(string)
+
+			switch {
+			case !ok:
+				fieldErrors = append(fieldErrors, map[string]any{"path": []any{"messageOverrides", locale, publicId}, "message": "is not a point of the catalog"})
+			case float64(len([]rune(message))) > maxLength:
+				fieldErrors = append(fieldErrors, map[string]any{"path": []any{"messageOverrides", locale, publicId}, "message": fmt.Sprintf("must be at most %d characters", int(maxLength))})
+			}
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		response := fakeApiErrorBody("The message overrides are not valid")
+		response["errors"] = fieldErrors
+		return http.StatusBadRequest, response
+	}
+
+	if overrides == nil {
+		overrides = map[string]any{}
+	}
+	f.messageOverrides = overrides
+
+	return http.StatusOK, map[string]any{"messageOverrides": f.messageOverrides}
+}
+
+// newId returns the next ID, which is predictable so that test runs are repeatable.
+func (f *fakeApi) newId() string {
+	f.nextId++
+	return fmt.Sprintf(fakeApiIdPattern, f.nextId)
+}
+
+func fakeApiFind(objects map[string]map[string]any, key string, kind string) (int, any) {
+	object, ok := objects[key]
+	if !ok {
+		return http.StatusNotFound, fakeApiErrorBody(kind + " not found")
+	}
+
+	return http.StatusOK, object
+}
+
+// fakeApiUpdate applies a partial update to an object, leaving its readOnly keys as they are.
+func fakeApiUpdate(objects map[string]map[string]any, key string, kind string, body map[string]any, readOnly ...string) (int, any) {
+	object, ok := objects[key]
+	if !ok {
+		return http.StatusNotFound, fakeApiErrorBody(kind + " not found")
+	}
+
+	for _, name := range readOnly {
+		delete(body, name)
+	}
+	mergeFakeApiObject(object, body, false)
+
+	return http.StatusOK, object
+}
+
+func fakeApiDelete(objects map[string]map[string]any, key string, kind string) (int, any) {
+	object, ok := objects[key]
+	if !ok {
+		return http.StatusNotFound, fakeApiErrorBody(kind + " not found")
+	}
+
+	delete(objects, key)
+
+	return http.StatusOK, object
+}
+
+// fakeApiRequire checks that body has each of the names, returning a validation error listing those
+// it doesn't.
+func fakeApiRequire(body map[string]any, names ...string) (map[string]any, bool) {
+	fieldErrors := []any{}
+	for _, name := range names {
+		if value, ok := body[name]; !ok || value == nil || value == "" {
+			fieldErrors = append(fieldErrors, map[string]any{"path": []any{name}, "message": "is required"})
+		}
+	}
+
+	if len(fieldErrors) == 0 {
+		return nil, true
+	}
+
+	response := fakeApiErrorBody("The request body is not valid")
+	response["errors"] = fieldErrors
+
+	return response, false
+}
+
+// mergeFakeApiObject applies a partial update: a null removes a value and anything else replaces it.
+// With deep set, objects are merged rather than replaced.
+func mergeFakeApiObject(object map[string]any, update map[string]any, deep bool) {
+	for name, value := range update {
+		if value == nil {
+			delete(object, name)
+			continue
+		}
+
+		if deep {
+			existing, existingIsObject := object[name].(map[string]any)
+			nested, nestedIsObject := value.(map[string]any)
+			if existingIsObject && nestedIsObject {
+				mergeFakeApiObject(existing, nested, true)
+				continue
+			}
+		}
+
+		object[name] = value
+	}
+}
+
+var fakeApiAliasSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// fakeApiAlias returns the alias the API gives a value list, such as `blocked-emails` for `Blocked Emails`.
+func fakeApiAlias(name string) string {
+	return strings.Trim(fakeApiAliasSeparators.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// fakeApiErrorBody is an error response that says what is wrong.
+func fakeApiErrorBody(description string) map[string]any {
+	return map[string]any{"errorDescription": description}
+}
+
+func writeFakeApiResponse(w http.ResponseWriter, statusCode int, response any) {
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// seed adds the objects the data source tests expect the tenant to have.
+func (f *fakeApi) seed() {
+	var seed struct {
+		ActionConfigurations []map[string]any `json:"actionConfigurations"`
+		Rules                []map[string]any `json:"rules"`
+		ValueLists           []map[string]any `json:"valueLists"`
+		CustomDataPoints     []map[string]any `json:"customDataPoints"`
+		Theme                map[string]any   `json:"theme"`
+		Catalog              map[string]any   `json:"catalog"`
+	}
+	if err := json.Unmarshal([]byte(fakeApiSeed), &seed); err != nil {
+		panic(err)
+	}
+
+	for _, actionConfiguration := range seed.ActionConfigurations {
+		actionConfiguration["tenantId"] = fakeApiTenantId
+		f.actionConfigurations[actionConfiguration["actionCode"].(string)] = actionConfiguration
+	}
+
+	for _, rule := range seed.Rules {
+		rule["tenantId"] = fakeApiTenantId
+		actionCode := rule["actionCode"].(string)
+		if f.rules[actionCode] == nil {
+			f.rules[actionCode] = map[string]map[string]any{}
+		}
+		f.rules[actionCode][rule["ruleId"].(string)] = rule
+	}
+
+	for _, valueList := range seed.ValueLists {
+		f.valueLists[valueList["alias"].(string)] = valueList
+	}
+
+	for _, customDataPoint := range seed.CustomDataPoints {
+		f.customDataPoints[customDataPoint["id"].(string)] = customDataPoint
+	}
+
+	f.theme = seed.Theme
+	f.catalog = seed.Catalog
+}
+
+const fakeApiSeed = `{
+	"actionConfigurations": [
+		{
+			"actionCode": "helloworld",
+			"defaultUserActionResult": "ALLOW",
+			"messagingTemplates": {"en": {"defaultTemplate": "hello world 123"}},
+			"verificationMethods": ["EMAIL_OTP", "AUTHENTICATOR_APP"],
+			"defaultVerificationMethod": "AUTHENTICATOR_APP"
+		},
+		{"actionCode": "terraform-acc-tests", "defaultUserActionResult": "CHALLENGE"},
+		{"actionCode": "terraform-acc-tests-action-rules", "defaultUserActionResult": "CHALLENGE"},
+		{"actionCode": "terraform-acc-tests-action-rules-partial", "defaultUserActionResult": "CHALLENGE"}
+	],
+	"rules": [
+		{
+			"actionCode": "terraform-acc-tests",
+			"ruleId": "2568fe20-851d-40f6-9c17-448dc484174c",
+			"name": "data-source-test",
+			"description": "hello world",
+			"isActive": false,
+			"priority": 1,
+			"type": "CHALLENGE",
+			"verificationMethods": ["EMAIL_OTP", "EMAIL_MAGIC_LINK", "AUTHENTICATOR_APP"],
+			"promptToEnrollVerificationMethods": ["PASSKEY"],
+			"defaultVerificationMethod": "EMAIL_OTP",
+			"conditions": {"and": [{"==": [{"var": "ip.isAnonymous"}, false]}]}
+		}
+	],
+	"valueLists": [
+		{"name": "Hello World Strings", "alias": "hello-world-strings", "itemType": "string", "isActive": true, "valueListItems": ["hello", "world"]},
+		{"name": "Hello World Numbers", "alias": "hello-world-numbers", "itemType": "number", "isActive": true, "valueListItems": [123, 456, 789]}
+	],
+	"customDataPoints": [
+		{
+			"id": "45930709-396e-440c-893e-8f67794dc345",
+			"name": "Terraform_Data_Source_Testing",
+			"dataType": "text",
+			"modelType": "action",
+			"description": "hello world",
+			"isPublic": false
+		}
+	],
+	"theme": {
+		"name": "Management-API-Testing",
+		"logoUrl": "https://res.cloudinary.com/authsignal/image/upload/v1716774524/m7bvgqjzr29tp69qcogr.jpg",
+		"faviconUrl": "https://res.cloudinary.com/authsignal/image/upload/v1716774530/nvjzmbqax2jiiwse3a40.jpg",
+		"watermarkUrl": "https://res.cloudinary.com/authsignal/image/upload/v1716774530/nvjzmbqax2jiiwse3a40.jpg",
+		"primaryColor": "#121282",
+		"borders": {
+			"buttonBorderRadius": 4, "buttonBorderWidth": 4, "cardBorderRadius": 8, "cardBorderWidth": 5,
+			"inputBorderRadius": 8, "inputBorderWidth": 4, "containerBorderRadius": 7
+		},
+		"colors": {
+			"buttonPrimaryText": "#7b5654", "buttonPrimaryBorder": "#9595dd", "buttonSecondaryText": "#8e8ee2",
+			"buttonSecondaryBackground": "#e88285", "buttonSecondaryBorder": "#6969cd", "cardBackground": "#9c2b2d",
+			"cardBorder": "#7d98cd", "inputBackground": "#c55e5d", "inputBorder": "#83a1cd", "link": "#3e568d",
+			"headingText": "#7373bd", "bodyText": "#bcbcf1", "containerBackground": "#a7767d",
+			"containerBorder": "#9a191d", "divider": "#6c8fd3", "icon": "#5959ad", "loader": "#7474bd",
+			"positive": "#85e5bd", "critical": "#c45f5d", "information": "#3b62ad", "hover": "#1a5fed",
+			"focus": "#8f82cd"
+		},
+		"pageBackground": {
+			"backgroundColor": "#427ab2",
+			"backgroundImageUrl": "https://res.cloudinary.com/authsignal/image/upload/v1716774650/scn2dj1eqvpg9uxuffsr.jpg"
+		},
+		"container": {
+			"contentAlignment": "right", "padding": 61, "logoAlignment": "right", "logoPosition": "inside",
+			"logoHeight": 113
+		},
+		"darkMode": {
+			"logoUrl": "https://res.cloudinary.com/authsignal/image/upload/v1716774524/m7bvgqjzr29tp69qcogr.png",
+			"faviconUrl": "https://res.cloudinary.com/authsignal/image/upload/v1716774530/nvjzmbqax2jiiwse3a40.png",
+			"watermarkUrl": "https://res.cloudinary.com/authsignal/image/upload/v1716774530/nvjzmbqax2jiiwse3a40.jpg",
+			"primaryColor": "#a7a7d7",
+			"borders": {
+				"buttonBorderRadius": 6, "buttonBorderWidth": 6, "cardBorderRadius": 3, "cardBorderWidth": 4,
+				"inputBorderRadius": 2, "inputBorderWidth": 1, "containerBorderRadius": 5
+			},
+			"colors": {
+				"buttonPrimaryText": "#a2818a", "buttonPrimaryBorder": "#8c8caa", "buttonSecondaryText": "#7a7a9a",
+				"buttonSecondaryBackground": "#9f7a7b", "buttonSecondaryBorder": "#7979aa", "cardBackground": "#91555a",
+				"cardBorder": "#97a1ba", "inputBackground": "#ac8b8a", "inputBorder": "#6d747a", "link": "#899bba",
+				"headingText": "#52526a", "bodyText": "#4040ba", "containerBackground": "#723b3a",
+				"containerBorder": "#ec939a", "divider": "#8994aa", "icon": "#21215a", "loader": "#4d4d5a",
+				"positive": "#4b585a", "critical": "#6a585a", "information": "#686e7a", "hover": "#5f646a",
+				"focus": "#55535a"
+			},
+			"pageBackground": {
+				"backgroundColor": "#89add1",
+				"backgroundImageUrl": "https://res.cloudinary.com/authsignal/image/upload/v1716774650/scn2dj1eqvpg9uxuffsr.png"
+			},
+			"container": {
+				"contentAlignment": "left", "padding": 58, "logoAlignment": "right", "logoPosition": "outside",
+				"logoHeight": 99
+			}
+		}
+	},
+	"catalog": {
+		"catalogVersion": 1,
+		"screens": [
+			{"id": "sms-code-entry", "label": "SMS code entry", "family": "otp"},
+			{"id": "email-code-entry", "label": "Email code entry", "family": "otp"}
+		],
+		"points": [
+			{
+				"publicId": "sms-code-entry.heading", "screen": "sms-code-entry", "role": "heading", "item": "heading",
+				"label": "Heading", "products": ["pre-built-ui"], "maxLength": 60,
+				"allowedPlaceholders": [], "allowedTags": [], "defaultCopy": {"en": "Enter the code"}
+			},
+			{
+				"publicId": "email-code-entry.heading", "screen": "email-code-entry", "role": "heading", "item": "heading",
+				"label": "Heading", "products": ["pre-built-ui"], "maxLength": 60,
+				"allowedPlaceholders": [], "allowedTags": [], "defaultCopy": {"en": "Check your email"}
+			}
+		]
+	}
+}`
+
+func TestFakeApi(t *testing.T) {
+	f := newFakeApi()
+	defer f.close()
+
+	send := func(method string, endpoint string, body string) (int, http.Header, map[string]any) {
+		var reader io.Reader
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+
+		req, _ := http.NewRequest(method, f.url()+endpoint, reader)
+		req.SetBasicAuth(fakeApiSecret, "")
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer res.Body.Close()
+
+		response := map[string]any{}
+		_ = json.NewDecoder(res.Body).Decode(&response)
+
+		return res.StatusCode, res.Header, response
+	}
+
+	t.Run("seed has only fields the client reads", func(t *testing.T) {
+		objects := map[string][]map[string]any{}
+		for _, actionConfiguration := range f.actionConfigurations {
+			objects["actions"] = append(objects["actions"], actionConfiguration)
+		}
+		for actionCode, rules := range f.rules {
+			for _, rule := range rules {
+				objects["actions/"+actionCode+"/rules"] = append(objects["actions/"+actionCode+"/rules"], rule)
+			}
+		}
+		for _, valueList := range f.valueLists {
+			objects["value-lists"] = append(objects["value-lists"], valueList)
+		}
+		for _, customDataPoint := range f.customDataPoints {
+			objects["custom-data-points"] = append(objects["custom-data-points"], customDataPoint)
+		}
+		objects["theme"] = []map[string]any{f.theme}
+		objects["tenant"] = []map[string]any{f.tenant}
+		objects["message-overrides/catalog"] = []map[string]any{f.catalog}
+
+		for _, endpoint := range sortedKeys(objects) {
+			_, responseShape := fakeApiShapes(strings.Split(endpoint, "/"))
+			for _, object := range objects[endpoint] {
+				if err := responseShape.check(object, ""); err != nil {
+					t.Errorf("seeded object under %s: %v", endpoint, err)
+				}
+			}
+		}
+	})
+
+	t.Run("seeded objects", func(t *testing.T) {
+		statusCode, _, rule := send(http.MethodGet, "/actions/terraform-acc-tests/rules/2568fe20-851d-40f6-9c17-448dc484174c", "")
+		if statusCode != http.StatusOK || rule["name"] != "data-source-test" || rule["tenantId"] != fakeApiTenantId {
+			t.Fatalf("bad rule: %d %v", statusCode, rule)
+		}
+	})
+
+	t.Run("rejects fields the client does not send", func(t *testing.T) {
+		statusCode, _, response := send(http.MethodPost, "/value-lists", `{"name":"Blocked Colours","itemType":"string","colour":"red"}`)
+		if statusCode != http.StatusBadRequest || !strings.Contains(fmt.Sprint(response["errorDescription"]), `"colour"`) {
+			t.Fatalf("expected the unknown field to be rejected. got : %d %v", statusCode, response)
+		}
+
+		statusCode, _, response = send(http.MethodPatch, "/theme", `{"colors":{"linkColour":"#000000"}}`)
+		if statusCode != http.StatusBadRequest || !strings.Contains(fmt.Sprint(response["errorDescription"]), `"colors.linkColour"`) {
+			t.Fatalf("expected the unknown nested field to be rejected. got : %d %v", statusCode, response)
+		}
+	})
+
+	t.Run("create, update and delete", func(t *testing.T) {
+		statusCode, _, valueList := send(http.MethodPost, "/value-lists", `{"name":"Blocked Emails","itemType":"string","valueListItems":["a"]}`)
+		if statusCode != http.StatusOK || valueList["alias"] != "blocked-emails" {
+			t.Fatalf("bad value list: %d %v", statusCode, valueList)
+		}
+
+		if statusCode, _, _ := send(http.MethodPost, "/value-lists", `{"name":"Blocked emails","itemType":"string"}`); statusCode != http.StatusConflict {
+			t.Fatalf("bad status code for a duplicate alias. expected: %d. got : %d", http.StatusConflict, statusCode)
+		}
+
+		statusCode, _, valueList = send(http.MethodPatch, "/value-lists/blocked-emails", `{"valueListItems":["a","c"]}`)
+		if statusCode != http.StatusOK || fmt.Sprint(valueList["valueListItems"]) != "[a c]" {
+			t.Fatalf("bad value list after replacing items: %d %v", statusCode, valueList)
+		}
+
+		statusCode, _, valueList = send(http.MethodPatch, "/value-lists/blocked-emails", `{"name":"Blocked Addresses","isActive":false}`)
+		if statusCode != http.StatusOK || valueList["alias"] != "blocked-addresses" || valueList["isActive"] != false {
+			t.Fatalf("bad updated value list: %d %v", statusCode, valueList)
+		}
+
+		if statusCode, _, _ := send(http.MethodDelete, "/value-lists/blocked-addresses", ""); statusCode != http.StatusOK {
+			t.Fatalf("bad status code for delete. expected: %d. got : %d", http.StatusOK, statusCode)
+		}
+		if statusCode, _, _ := send(http.MethodGet, "/value-lists/blocked-addresses", ""); statusCode != http.StatusNotFound {
+			t.Fatalf("bad status code after delete. expected: %d. got : %d", http.StatusNotFound, statusCode)
+		}
+	})
+
+	t.Run("partial theme updates", func(t *testing.T) {
+		statusCode, _, theme := send(http.MethodPatch, "/theme", `{"colors":{"link":"#000000"},"logoUrl":null}`)
+		colors, _ := theme["colors"].(map[string]any)
+		if statusCode != http.StatusOK || colors["link"] != "#000000" || colors["icon"] != "#5959ad" {
+			t.Fatalf("bad theme colors: %d %v", statusCode, colors)
+		}
+		if _, ok := theme["logoUrl"]; ok {
+			t.Fatalf("expected a null to remove logoUrl, got: %v", theme["logoUrl"])
+		}
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		ctx := context.Background()
+
+		statusCode, header, response := send(http.MethodPost, "/value-lists", `{"name":"Missing Item Type"}`)
+		body, _ := json.Marshal(response)
+		err := newApiError(http.MethodPost, "/value-lists", &http.Response{StatusCode: statusCode, Header: header}, body)
+
+		diags := apiErrorDiagnostics("summary", "detail", err, resourceApiErrorFields(ctx, NewValueListResource(), nil))
+		if len(diags) != 1 {
+			t.Fatalf("expected a single diagnostic, got: %v", diags)
+		}
+		if withPath, ok := diags[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("item_type")) {
+			t.Fatalf("expected the error on item_type, got: %v", diags[0])
+		}
+
+		statusCode, header, response = send(http.MethodPatch, "/message-overrides", `{"messageOverrides":{"en":{"no-such-screen.heading":"Hello"}}}`)
+		body, _ = json.Marshal(response)
+		err = newApiError(http.MethodPatch, "/message-overrides", &http.Response{StatusCode: statusCode, Header: header}, body)
+
+		diags = apiErrorDiagnostics("summary", "detail", err, resourceApiErrorFields(ctx, NewMessageOverridesResource(), messageOverridesApiFieldRenames))
+		if len(diags) != 1 {
+			t.Fatalf("expected a single diagnostic, got: %v", diags)
+		}
+		if withPath, ok := diags[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("overrides").AtMapKey("en").AtMapKey("no-such-screen.heading")) {
+			t.Fatalf("expected the error on the override, got: %v", diags[0])
+		}
+	})
+
+	t.Run("wrong api secret", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, f.url()+"/theme", nil)
+		req.SetBasicAuth("wrong", "")
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("bad status code. expected: %d. got : %d", http.StatusUnauthorized, res.StatusCode)
+		}
+	})
+
+	for _, statusCode := range []int{http.StatusNotFound, http.StatusConflict, http.StatusTooManyRequests, http.StatusInternalServerError} {
+		t.Run(fmt.Sprintf("injected %d", statusCode), func(t *testing.T) {
+			f.failNext(http.MethodGet, "/actions/terraform-acc-tests", statusCode, 1)
+
+			got, header, response := send(http.MethodGet, "/actions/terraform-acc-tests/rules/2568fe20-851d-40f6-9c17-448dc484174c", "")
+			if got != statusCode || response["errorDescription"] != http.StatusText(statusCode) || header.Get("X-Request-Id") == "" {
+				t.Fatalf("bad injected error: %d %v", got, response)
+			}
+			if statusCode == http.StatusTooManyRequests && header.Get("Retry-After") != "0" {
+				t.Fatalf("bad Retry-After. expected: 0. got : %q", header.Get("Retry-After"))
+			}
+
+			if got, _, _ := send(http.MethodGet, "/actions/terraform-acc-tests/rules/2568fe20-851d-40f6-9c17-448dc484174c", ""); got != http.StatusOK {
+				t.Fatalf("expected the error only once, got: %d", got)
+			}
+		})
+	}
+
+	t.Run("injected errors match the method", func(t *testing.T) {
+		f.failNext(http.MethodDelete, "/theme", http.StatusInternalServerError, 1)
+
+		if statusCode, _, _ := send(http.MethodGet, "/theme", ""); statusCode != http.StatusOK {
+			t.Fatalf("bad status code. expected: %d. got : %d", http.StatusOK, statusCode)
+		}
+	})
+
+	sent := f.sent()
+	if len(sent) == 0 || sent[0] != "GET /actions/terraform-acc-tests/rules/2568fe20-851d-40f6-9c17-448dc484174c" {
+		t.Fatalf("bad requests sent: %v", sent)
+	}
+}
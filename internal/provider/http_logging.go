@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxLoggedBodySize is how much of a request or response body is logged, after redaction.
+const maxLoggedBodySize = 16 * 1024
+
+// redactedValue replaces secrets in logged headers and bodies.
+const redactedValue = "[REDACTED]"
+
+// redactedHeaders are the headers whose values are never logged.
+var redactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// redactedKeyParts mark the keys of JSON bodies whose values are never logged, matched against the
+// lower-cased key with `_` and `-` removed.
+var redactedKeyParts = []string{"secret", "password", "token", "authorization", "apikey", "credential"}
+
+// requestIdHeaders are the response headers that may carry the ID of a request, in order of preference.
+var requestIdHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "Apigw-Requestid", "X-Amz-Cf-Id"}
+
+// responseRequestId returns the ID of the request a response is to, or "" if it has none.
+func responseRequestId(header http.Header) string {
+	for _, name := range requestIdHeaders {
+		if requestId := header.Get(name); requestId != "" {
+			return requestId
+		}
+	}
+
+	return ""
+}
+
+// loggingTransport logs every request to the Management API through tflog: the method, path, status,
+// latency and request ID at debug level, and the headers and bodies, redacted, at trace level. It logs
+// through the request's context, which authsignalClient.requestContext sets up to mask the API secret.
+// TF_LOG_PROVIDER_AUTHSIGNAL sets the level.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.SetField(req.Context(), "http_method", req.Method)
+	ctx = tflog.SetField(ctx, "http_path", req.URL.Path)
+
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			content, _ := io.ReadAll(body)
+			body.Close()
+			tflog.Trace(ctx, "Sending Authsignal API request", map[string]any{
+				"http_request_headers": redactHeaders(req.Header),
+				"http_request_body":    redactBody(content),
+			})
+		}
+	} else {
+		tflog.Trace(ctx, "Sending Authsignal API request", map[string]any{
+			"http_request_headers": redactHeaders(req.Header),
+		})
+	}
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	ctx = tflog.SetField(ctx, "http_duration_ms", time.Since(start).Milliseconds())
+
+	if err != nil {
+		tflog.Debug(ctx, "Authsignal API request failed", map[string]any{"error": err.Error()})
+		return nil, err
+	}
+
+	ctx = tflog.SetField(ctx, "http_status_code", res.StatusCode)
+
+	if requestId := responseRequestId(res.Header); requestId != "" {
+		ctx = tflog.SetField(ctx, "http_request_id", requestId)
+	}
+
+	tflog.Debug(ctx, "Received Authsignal API response")
+
+	// The body is read in full to log it, and handed on from memory. Management API responses are small.
+	content, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		tflog.Debug(ctx, "Authsignal API response failed", map[string]any{"error": err.Error()})
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(content))
+
+	tflog.Trace(ctx, "Authsignal API response body", map[string]any{
+		"http_response_headers": redactHeaders(res.Header),
+		"http_response_body":    redactBody(content),
+	})
+
+	return res, nil
+}
+
+// redactHeaders returns headers as a map for logging, without the values of redactedHeaders.
+func redactHeaders(headers http.Header) map[string]string {
+	logged := make(map[string]string, len(headers))
+	for name, values := range headers {
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			logged[name] = redactedValue
+			continue
+		}
+		logged[name] = strings.Join(values, ", ")
+	}
+
+	return logged
+}
+
+// redactBody returns a body for logging. The values of secret-looking keys in a JSON body are
+// redacted; a body that is not JSON is logged as is. Either way, it is cut short at maxLoggedBodySize.
+func redactBody(body []byte) string {
+	var value any
+	if err := json.Unmarshal(body, &value); err == nil {
+		if redacted, err := json.Marshal(redactJson(value)); err == nil {
+			body = redacted
+		}
+	}
+
+	if len(body) > maxLoggedBodySize {
+		return string(body[:maxLoggedBodySize]) + "...(truncated)"
+	}
+
+	return string(body)
+}
+
+func redactJson(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, item := range value {
+			if isSecretKey(key) {
+				value[key] = redactedValue
+			} else {
+				value[key] = redactJson(item)
+			}
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = redactJson(item)
+		}
+	}
+
+	return value
+}
+
+func isSecretKey(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	for _, part := range redactedKeyParts {
+		if strings.Contains(normalized, part) {
+			return true
+		}
+	}
+
+	return false
+}
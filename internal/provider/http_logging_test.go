@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"ruleId": "a2d9670f", "webhookSecret": "whsec"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflog.MaskLogStrings(tflogtest.RootLogger(context.Background(), &output), "top-secret")
+
+	client := &http.Client{Transport: newRetryTransport(&loggingTransport{next: http.DefaultTransport}, 0, defaultRetryMaxWait)}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v1/management/actions/login/rules", strings.NewReader(`{"name": "Block", "apiSecret": "other", "note": "top-secret"}`))
+	req.SetBasicAuth("top-secret", "")
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode != http.StatusCreated || string(body) != `{"ruleId": "a2d9670f", "webhookSecret": "whsec"}` {
+		t.Fatalf("expected the response to pass through unchanged. got: %v, %s", res.StatusCode, body)
+	}
+
+	logged := output.String()
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var response map[string]any
+	for _, entry := range entries {
+		if entry["@message"] == "Received Authsignal API response" {
+			response = entry
+		}
+	}
+
+	if response == nil || response["http_method"] != "POST" || response["http_path"] != "/v1/management/actions/login/rules" ||
+		response["http_status_code"] != float64(http.StatusCreated) || response["http_request_id"] != "req-123" || response["http_duration_ms"] == nil {
+		t.Fatalf("bad response entry: %v", response)
+	}
+
+	for _, secret := range []string{"top-secret", "other", "whsec", "session=abc", "Basic "} {
+		if strings.Contains(logged, secret) {
+			t.Fatalf("expected %q to be redacted from the logs: %s", secret, logged)
+		}
+	}
+
+	if !strings.Contains(logged, `\"name\":\"Block\"`) {
+		t.Fatalf("expected the request body to be logged: %s", logged)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	testCases := map[string]string{
+		`{"api_secret": "a", "nested": [{"accessToken": "b", "value": "c"}]}`: `{"api_secret":"[REDACTED]","nested":[{"accessToken":"[REDACTED]","value":"c"}]}`,
+		`not json`: `not json`,
+		``:         ``,
+	}
+
+	for body, expected := range testCases {
+		if got := redactBody([]byte(body)); got != expected {
+			t.Fatalf("bad redaction of %q. expected: %q, got : %q", body, expected, got)
+		}
+	}
+
+	if got := redactBody(bytes.Repeat([]byte("a"), maxLoggedBodySize+1)); len(got) != maxLoggedBodySize+len("...(truncated)") {
+		t.Fatalf("expected a long body to be truncated. got %d bytes", len(got))
+	}
+}
+
+func TestRequestContextMasksTheClientsSecret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"echo": "client-secret"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	client := newAuthsignalClient(server.URL, "tenant-1", "client-secret")
+	ctx := client.requestContext(tflogtest.RootLogger(context.Background(), &output))
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/theme", nil)
+	res, err := (&http.Client{Transport: &loggingTransport{next: http.DefaultTransport}}).Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	logged := output.String()
+	if strings.Contains(logged, "client-secret") || !strings.Contains(logged, `"authsignal_tenant_id":"tenant-1"`) {
+		t.Fatalf("expected the logs to have the tenant but not the secret: %s", logged)
+	}
+}
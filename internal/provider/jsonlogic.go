@@ -0,0 +1,516 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// evaluateJsonLogic applies a decoded JsonLogic document to a decoded data document, following the
+// semantics documented at https://jsonlogic.com/operations.html. It supports the same operators that
+// rule conditions are validated against, so anything that passes validation can be evaluated locally.
+func evaluateJsonLogic(logic any, data any) (any, error) {
+	switch value := logic.(type) {
+	case []any:
+		results := make([]any, 0, len(value))
+		for _, item := range value {
+			result, err := evaluateJsonLogic(item, data)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+		return results, nil
+	case map[string]any:
+		if len(value) != 1 {
+			return value, nil
+		}
+
+		for operator, args := range value {
+			return evaluateJsonLogicOperation(operator, jsonLogicArgs(args), data)
+		}
+	}
+
+	return logic, nil
+}
+
+// JsonLogic lets a single argument be written without the surrounding array.
+func jsonLogicArgs(args any) []any {
+	if list, ok := args.([]any); ok {
+		return list
+	}
+	return []any{args}
+}
+
+func evaluateJsonLogicOperation(operator string, args []any, data any) (any, error) {
+	// These operators decide for themselves which arguments to evaluate, and against which data.
+	switch operator {
+	case "if", "?:":
+		return evaluateJsonLogicIf(args, data)
+	case "and", "or":
+		return evaluateJsonLogicAndOr(operator, args, data)
+	case "map", "filter", "all", "none", "some", "reduce":
+		return evaluateJsonLogicArrayOperation(operator, args, data)
+	}
+
+	values := make([]any, 0, len(args))
+	for _, arg := range args {
+		value, err := evaluateJsonLogic(arg, data)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	arg := func(i int) any {
+		if i < len(values) {
+			return values[i]
+		}
+		return nil
+	}
+
+	switch operator {
+	case "var":
+		return jsonLogicVar(arg(0), arg(1), data), nil
+	case "missing":
+		return jsonLogicMissing(values, data), nil
+	case "missing_some":
+		return jsonLogicMissingSome(arg(0), arg(1), data), nil
+	case "==":
+		return jsonLogicLooseEqual(arg(0), arg(1)), nil
+	case "===":
+		return jsonLogicStrictEqual(arg(0), arg(1)), nil
+	case "!=":
+		return !jsonLogicLooseEqual(arg(0), arg(1)), nil
+	case "!==":
+		return !jsonLogicStrictEqual(arg(0), arg(1)), nil
+	case "!":
+		return !jsonLogicTruthy(arg(0)), nil
+	case "!!":
+		return jsonLogicTruthy(arg(0)), nil
+	case ">":
+		return jsonLogicLess(arg(1), arg(0)), nil
+	case ">=":
+		return jsonLogicLooseEqual(arg(0), arg(1)) || jsonLogicLess(arg(1), arg(0)), nil
+	case "<", "<=":
+		return jsonLogicBetween(operator, values), nil
+	case "max", "min":
+		return jsonLogicMinMax(operator, values), nil
+	case "+":
+		sum := 0.0
+		for _, value := range values {
+			sum += jsonLogicNumber(value)
+		}
+		return sum, nil
+	case "*":
+		product := 1.0
+		for _, value := range values {
+			product *= jsonLogicNumber(value)
+		}
+		return product, nil
+	case "-":
+		if len(values) == 1 {
+			return -jsonLogicNumber(arg(0)), nil
+		}
+		return jsonLogicNumber(arg(0)) - jsonLogicNumber(arg(1)), nil
+	case "/":
+		return jsonLogicNumber(arg(0)) / jsonLogicNumber(arg(1)), nil
+	case "%":
+		return math.Mod(jsonLogicNumber(arg(0)), jsonLogicNumber(arg(1))), nil
+	case "merge":
+		merged := []any{}
+		for _, value := range values {
+			merged = append(merged, jsonLogicArgs(value)...)
+		}
+		return merged, nil
+	case "in":
+		return jsonLogicIn(arg(0), arg(1)), nil
+	case "cat":
+		var builder strings.Builder
+		for _, value := range values {
+			builder.WriteString(jsonLogicString(value))
+		}
+		return builder.String(), nil
+	case "substr":
+		return jsonLogicSubstr(jsonLogicString(arg(0)), arg(1), arg(2), len(values) > 2), nil
+	}
+
+	return nil, fmt.Errorf("unsupported JsonLogic operator %q", operator)
+}
+
+func evaluateJsonLogicIf(args []any, data any) (any, error) {
+	for i := 0; i+1 < len(args); i += 2 {
+		condition, err := evaluateJsonLogic(args[i], data)
+		if err != nil {
+			return nil, err
+		}
+
+		if jsonLogicTruthy(condition) {
+			return evaluateJsonLogic(args[i+1], data)
+		}
+	}
+
+	if len(args)%2 == 1 {
+		return evaluateJsonLogic(args[len(args)-1], data)
+	}
+
+	return nil, nil
+}
+
+// and returns the first falsy argument, or the last one; or returns the first truthy argument, or
+// the last one. Both stop evaluating as soon as the result is decided.
+func evaluateJsonLogicAndOr(operator string, args []any, data any) (any, error) {
+	var result any
+	for _, arg := range args {
+		value, err := evaluateJsonLogic(arg, data)
+		if err != nil {
+			return nil, err
+		}
+
+		result = value
+		if jsonLogicTruthy(value) == (operator == "or") {
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
+
+func evaluateJsonLogicArrayOperation(operator string, args []any, data any) (any, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	scope, err := evaluateJsonLogic(args[0], data)
+	if err != nil {
+		return nil, err
+	}
+
+	items, _ := scope.([]any)
+
+	var logic any
+	if len(args) > 1 {
+		logic = args[1]
+	}
+
+	if operator == "reduce" {
+		var accumulator any
+		if len(args) > 2 {
+			if accumulator, err = evaluateJsonLogic(args[2], data); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, item := range items {
+			if accumulator, err = evaluateJsonLogic(logic, map[string]any{"current": item, "accumulator": accumulator}); err != nil {
+				return nil, err
+			}
+		}
+
+		return accumulator, nil
+	}
+
+	results := []any{}
+	for _, item := range items {
+		result, err := evaluateJsonLogic(logic, item)
+		if err != nil {
+			return nil, err
+		}
+
+		switch operator {
+		case "map":
+			results = append(results, result)
+		case "filter":
+			if jsonLogicTruthy(result) {
+				results = append(results, item)
+			}
+		case "all":
+			if !jsonLogicTruthy(result) {
+				return false, nil
+			}
+		case "none":
+			if jsonLogicTruthy(result) {
+				return false, nil
+			}
+		case "some":
+			if jsonLogicTruthy(result) {
+				return true, nil
+			}
+		}
+	}
+
+	switch operator {
+	case "all":
+		return len(items) > 0, nil
+	case "none":
+		return true, nil
+	case "some":
+		return false, nil
+	}
+
+	return results, nil
+}
+
+// jsonLogicVar looks up a dot separated path in the data, where numeric segments index into arrays.
+// An empty path returns the data itself, and a path that is not found returns the fallback.
+func jsonLogicVar(name any, fallback any, data any) any {
+	if name == nil || name == "" {
+		return data
+	}
+
+	current := data
+	for _, segment := range strings.Split(jsonLogicString(name), ".") {
+		switch value := current.(type) {
+		case map[string]any:
+			next, ok := value[segment]
+			if !ok {
+				return fallback
+			}
+			current = next
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(value) {
+				return fallback
+			}
+			current = value[index]
+		default:
+			return fallback
+		}
+	}
+
+	return current
+}
+
+func jsonLogicMissing(keys []any, data any) []any {
+	if len(keys) == 1 {
+		if list, ok := keys[0].([]any); ok {
+			keys = list
+		}
+	}
+
+	missing := []any{}
+	for _, key := range keys {
+		value := jsonLogicVar(key, nil, data)
+		if value == nil || value == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	return missing
+}
+
+func jsonLogicMissingSome(need any, keys any, data any) []any {
+	options := jsonLogicArgs(keys)
+	missing := jsonLogicMissing([]any{options}, data)
+
+	if float64(len(options)-len(missing)) >= jsonLogicNumber(need) {
+		return []any{}
+	}
+
+	return missing
+}
+
+func jsonLogicBetween(operator string, values []any) bool {
+	less := func(a any, b any) bool {
+		if operator == "<=" {
+			return jsonLogicLess(a, b) || jsonLogicLooseEqual(a, b)
+		}
+		return jsonLogicLess(a, b)
+	}
+
+	if len(values) < 2 {
+		return false
+	}
+
+	if !less(values[0], values[1]) {
+		return false
+	}
+
+	return len(values) < 3 || less(values[1], values[2])
+}
+
+func jsonLogicMinMax(operator string, values []any) any {
+	if len(values) == 0 {
+		return nil
+	}
+
+	result := jsonLogicNumber(values[0])
+	for _, value := range values[1:] {
+		if operator == "max" {
+			result = math.Max(result, jsonLogicNumber(value))
+		} else {
+			result = math.Min(result, jsonLogicNumber(value))
+		}
+	}
+
+	return result
+}
+
+func jsonLogicIn(needle any, haystack any) bool {
+	switch value := haystack.(type) {
+	case string:
+		return strings.Contains(value, jsonLogicString(needle))
+	case []any:
+		for _, item := range value {
+			if jsonLogicStrictEqual(needle, item) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func jsonLogicSubstr(source string, start any, length any, hasLength bool) string {
+	runes := []rune(source)
+
+	from := int(jsonLogicNumber(start))
+	if from < 0 {
+		from = max(len(runes)+from, 0)
+	}
+	from = min(from, len(runes))
+
+	to := len(runes)
+	if hasLength {
+		count := int(jsonLogicNumber(length))
+		if count < 0 {
+			to = max(len(runes)+count, from)
+		} else {
+			to = min(from+count, len(runes))
+		}
+	}
+
+	return string(runes[from:to])
+}
+
+// jsonLogicTruthy follows JavaScript's truthiness, except that an empty array is falsy.
+func jsonLogicTruthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case string:
+		return v != ""
+	case []any:
+		return len(v) > 0
+	}
+
+	return true
+}
+
+// jsonLogicNumber converts a value to a number the way JavaScript's Number() does.
+func jsonLogicNumber(value any) float64 {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case float64:
+		return v
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if trimmed == "" {
+			return 0
+		}
+		number, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil {
+			return math.NaN()
+		}
+		return number
+	case []any:
+		if len(v) == 0 {
+			return 0
+		}
+		if len(v) == 1 {
+			return jsonLogicNumber(v[0])
+		}
+	}
+
+	return math.NaN()
+}
+
+func jsonLogicString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if item == nil {
+				parts = append(parts, "")
+				continue
+			}
+			parts = append(parts, jsonLogicString(item))
+		}
+		return strings.Join(parts, ",")
+	}
+
+	return "[object Object]"
+}
+
+func jsonLogicStrictEqual(a any, b any) bool {
+	switch a.(type) {
+	case nil, bool, float64, string:
+		return a == b
+	}
+
+	return false
+}
+
+// jsonLogicLooseEqual follows JavaScript's == for the types a JSON document can hold.
+func jsonLogicLooseEqual(a any, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if reflect.TypeOf(a) == reflect.TypeOf(b) {
+		switch a.(type) {
+		case []any, map[string]any:
+			return false
+		}
+		return a == b
+	}
+
+	_, aIsString := a.(string)
+	_, bIsString := b.(string)
+	_, aIsNumber := a.(float64)
+	_, bIsNumber := b.(float64)
+	_, aIsBool := a.(bool)
+	_, bIsBool := b.(bool)
+
+	switch {
+	case aIsBool || bIsBool || (aIsNumber && bIsString) || (aIsString && bIsNumber):
+		return jsonLogicNumber(a) == jsonLogicNumber(b)
+	case aIsString || aIsNumber:
+		return jsonLogicLooseEqual(a, jsonLogicString(b))
+	case bIsString || bIsNumber:
+		return jsonLogicLooseEqual(jsonLogicString(a), b)
+	}
+
+	return false
+}
+
+// jsonLogicLess follows JavaScript's <, which compares strings lexically and anything else as numbers.
+func jsonLogicLess(a any, b any) bool {
+	aString, aIsString := a.(string)
+	bString, bIsString := b.(string)
+	if aIsString && bIsString {
+		return aString < bString
+	}
+
+	return jsonLogicNumber(a) < jsonLogicNumber(b)
+}
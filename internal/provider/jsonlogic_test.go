@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJsonLogicEvaluation(t *testing.T) {
+	data := `{
+		"ip": {"isAnonymous": false, "countryCode": "NZ"},
+		"user": {"email": "jane@example.com"},
+		"custom": {"riskScore": 85, "tags": ["vip", "beta"], "accountType": null},
+		"valueLists": {"blocked-emails": ["bad@example.com"]}
+	}`
+
+	testCases := []struct {
+		logic    string
+		expected any
+	}{
+		{logic: `{"var":"ip.countryCode"}`, expected: "NZ"},
+		{logic: `{"var":["ip.region","unknown"]}`, expected: "unknown"},
+		{logic: `{"var":"custom.tags.1"}`, expected: "beta"},
+		{logic: `{"var":"custom.accountType"}`, expected: nil},
+		{logic: `{"==":[{"var":"ip.isAnonymous"},false]}`, expected: true},
+		{logic: `{"==":[{"var":"custom.riskScore"},"85"]}`, expected: true},
+		{logic: `{"===":[{"var":"custom.riskScore"},"85"]}`, expected: false},
+		{logic: `{"!=":[{"var":"ip.countryCode"},"AU"]}`, expected: true},
+		{logic: `{">=":[{"var":"custom.riskScore"},80]}`, expected: true},
+		{logic: `{"<":[10,{"var":"custom.riskScore"},90]}`, expected: true},
+		{logic: `{"<=":[10,{"var":"custom.riskScore"},80]}`, expected: false},
+		{logic: `{"in":[{"var":"ip.countryCode"},["NZ","AU"]]}`, expected: true},
+		{logic: `{"in":["vip",{"var":"custom.tags"}]}`, expected: true},
+		{logic: `{"in":["example",{"var":"user.email"}]}`, expected: true},
+		{logic: `{"in":[{"var":"user.email"},{"var":"valueLists.blocked-emails"}]}`, expected: false},
+		{logic: `{"and":[true,"a",0]}`, expected: 0.0},
+		{logic: `{"or":[false,"",{"var":"ip.countryCode"}]}`, expected: "NZ"},
+		{logic: `{"!":[{"var":"custom.accountType"}]}`, expected: true},
+		{logic: `{"!!":[[]]}`, expected: false},
+		{logic: `{"if":[{">":[{"var":"custom.riskScore"},90]},"BLOCK",{">":[{"var":"custom.riskScore"},50]},"CHALLENGE","ALLOW"]}`, expected: "CHALLENGE"},
+		{logic: `{"max":[1,{"var":"custom.riskScore"},3]}`, expected: 85.0},
+		{logic: `{"+":[1,"2",3]}`, expected: 6.0},
+		{logic: `{"-":[5]}`, expected: -5.0},
+		{logic: `{"%":[{"var":"custom.riskScore"},10]}`, expected: 5.0},
+		{logic: `{"cat":["score: ",{"var":"custom.riskScore"}]}`, expected: "score: 85"},
+		{logic: `{"substr":["authsignal",-6,3]}`, expected: "sig"},
+		{logic: `{"merge":[[1],2,[3,4]]}`, expected: []any{1.0, 2.0, 3.0, 4.0}},
+		{logic: `{"missing":["ip.countryCode","device.id"]}`, expected: []any{"device.id"}},
+		{logic: `{"missing_some":[1,["ip.countryCode","device.id"]]}`, expected: []any{}},
+		{logic: `{"some":[{"var":"custom.tags"},{"==":[{"var":""},"beta"]}]}`, expected: true},
+		{logic: `{"all":[[],{"var":""}]}`, expected: false},
+		{logic: `{"none":[{"var":"custom.tags"},{"==":[{"var":""},"alpha"]}]}`, expected: true},
+		{logic: `{"filter":[[1,2,3,4],{">":[{"var":""},2]}]}`, expected: []any{3.0, 4.0}},
+		{logic: `{"map":[[1,2],{"*":[{"var":""},2]}]}`, expected: []any{2.0, 4.0}},
+		{logic: `{"reduce":[[1,2,3],{"+":[{"var":"current"},{"var":"accumulator"}]},10]}`, expected: 16.0},
+	}
+
+	var payload any
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.logic, func(t *testing.T) {
+			var logic any
+			if err := json.Unmarshal([]byte(testCase.logic), &logic); err != nil {
+				t.Fatalf("failed to unmarshal logic: %v", err)
+			}
+
+			result, err := evaluateJsonLogic(logic, payload)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(result, testCase.expected) {
+				t.Fatalf("bad result. expected: %#v. got : %#v", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestJsonLogicRejectsUnknownOperators(t *testing.T) {
+	var logic any
+	if err := json.Unmarshal([]byte(`{"and":[{"equals":[1,1]}]}`), &logic); err != nil {
+		t.Fatalf("failed to unmarshal logic: %v", err)
+	}
+
+	if _, err := evaluateJsonLogic(logic, nil); err == nil {
+		t.Fatalf("expected an error for an unknown operator")
+	}
+}
@@ -0,0 +1,394 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/authsignal/authsignal-management-go/v6"
+)
+
+// The Management API client sends its requests through http.DefaultTransport, and takes neither an
+// *http.Client nor a context. Until it does, each call is made with a client whose host is the
+// managementRelay, a listener on the loopback interface that sends the call's requests on to c.host
+// unchanged, through c.httpClient and with the context of the call. The client keeps its own paths,
+// request and response types, and authentication; only where its requests go changes.
+
+func (c *authsignalClient) CreateRule(ctx context.Context, actionCode string, rule authsignal.Rule) (*authsignal.RuleResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.RuleResponse, int, error) {
+		return client.CreateRule(actionCode, rule)
+	})
+}
+
+func (c *authsignalClient) GetRule(ctx context.Context, actionCode string, ruleId string) (*authsignal.RuleResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.RuleResponse, int, error) {
+		return client.GetRule(actionCode, ruleId)
+	})
+}
+
+func (c *authsignalClient) UpdateRule(ctx context.Context, actionCode string, ruleId string, rule authsignal.Rule) (*authsignal.RuleResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.RuleResponse, int, error) {
+		return client.UpdateRule(actionCode, ruleId, rule)
+	})
+}
+
+func (c *authsignalClient) DeleteRule(ctx context.Context, actionCode string, ruleId string) (*authsignal.RuleResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.RuleResponse, int, error) {
+		return client.DeleteRule(actionCode, ruleId)
+	})
+}
+
+func (c *authsignalClient) CreateActionConfiguration(ctx context.Context, actionConfiguration authsignal.ActionConfiguration) (*authsignal.ActionConfigurationResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.ActionConfigurationResponse, int, error) {
+		return client.CreateActionConfiguration(actionConfiguration)
+	})
+}
+
+func (c *authsignalClient) GetActionConfiguration(ctx context.Context, actionCode string) (*authsignal.ActionConfigurationResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.ActionConfigurationResponse, int, error) {
+		return client.GetActionConfiguration(actionCode)
+	})
+}
+
+func (c *authsignalClient) UpdateActionConfiguration(ctx context.Context, actionCode string, actionConfiguration authsignal.ActionConfiguration) (*authsignal.ActionConfigurationResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.ActionConfigurationResponse, int, error) {
+		return client.UpdateActionConfiguration(actionCode, actionConfiguration)
+	})
+}
+
+func (c *authsignalClient) DeleteActionConfiguration(ctx context.Context, actionCode string) (*authsignal.ActionConfigurationResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.ActionConfigurationResponse, int, error) {
+		return client.DeleteActionConfiguration(actionCode)
+	})
+}
+
+func (c *authsignalClient) CreateValueList(ctx context.Context, valueList authsignal.ValueList) (*authsignal.ValueListResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.ValueListResponse, int, error) {
+		return client.CreateValueList(valueList)
+	})
+}
+
+func (c *authsignalClient) GetValueList(ctx context.Context, alias string) (*authsignal.ValueListResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.ValueListResponse, int, error) {
+		return client.GetValueList(alias)
+	})
+}
+
+func (c *authsignalClient) UpdateValueList(ctx context.Context, alias string, valueList authsignal.ValueList) (*authsignal.ValueListResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.ValueListResponse, int, error) {
+		return client.UpdateValueList(alias, valueList)
+	})
+}
+
+// getValueListVersion is GetValueList, which also returns the ETag the Management API sent with the
+// value list, or "" without one.
+func (c *authsignalClient) getValueListVersion(ctx context.Context, alias string) (*authsignal.ValueListResponse, string, int, error) {
+	return conditionalManagementCall(ctx, c, "", func(client *authsignal.Client) (*authsignal.ValueListResponse, int, error) {
+		return client.GetValueList(alias)
+	})
+}
+
+// updateValueListVersion is UpdateValueList, made only if the value list still has the ETag etag.
+// The Management API responds with a 412 when it no longer does. An empty etag updates the value list
+// whatever its version.
+func (c *authsignalClient) updateValueListVersion(ctx context.Context, alias string, etag string, valueList authsignal.ValueList) (*authsignal.ValueListResponse, int, error) {
+	response, _, statusCode, err := conditionalManagementCall(ctx, c, etag, func(client *authsignal.Client) (*authsignal.ValueListResponse, int, error) {
+		return client.UpdateValueList(alias, valueList)
+	})
+
+	return response, statusCode, err
+}
+
+func (c *authsignalClient) DeleteValueList(ctx context.Context, alias string) (*authsignal.ValueListResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.ValueListResponse, int, error) {
+		return client.DeleteValueList(alias)
+	})
+}
+
+func (c *authsignalClient) CreateCustomDataPoint(ctx context.Context, customDataPoint authsignal.CustomDataPoint) (*authsignal.CustomDataPointResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.CustomDataPointResponse, int, error) {
+		return client.CreateCustomDataPoint(customDataPoint)
+	})
+}
+
+func (c *authsignalClient) GetCustomDataPoint(ctx context.Context, id string) (*authsignal.CustomDataPointResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.CustomDataPointResponse, int, error) {
+		return client.GetCustomDataPoint(id)
+	})
+}
+
+func (c *authsignalClient) UpdateCustomDataPoint(ctx context.Context, id string, customDataPoint authsignal.CustomDataPoint) (*authsignal.CustomDataPointResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.CustomDataPointResponse, int, error) {
+		return client.UpdateCustomDataPoint(id, customDataPoint)
+	})
+}
+
+func (c *authsignalClient) DeleteCustomDataPoint(ctx context.Context, id string) (*authsignal.CustomDataPointResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.CustomDataPointResponse, int, error) {
+		return client.DeleteCustomDataPoint(id)
+	})
+}
+
+func (c *authsignalClient) GetTheme(ctx context.Context) (*authsignal.ThemeResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.ThemeResponse, int, error) {
+		return client.GetTheme()
+	})
+}
+
+func (c *authsignalClient) UpdateTheme(ctx context.Context, theme authsignal.Theme) (*authsignal.ThemeResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.ThemeResponse, int, error) {
+		return client.UpdateTheme(theme)
+	})
+}
+
+func (c *authsignalClient) GetTenant(ctx context.Context) (*authsignal.TenantResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.TenantResponse, int, error) {
+		return client.GetTenant()
+	})
+}
+
+func (c *authsignalClient) UpdateTenant(ctx context.Context, settings authsignal.TenantSettings) (*authsignal.TenantResponse, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.TenantResponse, int, error) {
+		return client.UpdateTenant(settings)
+	})
+}
+
+func (c *authsignalClient) GetMessageOverrides(ctx context.Context) (*authsignal.MessageOverridesBody, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.MessageOverridesBody, int, error) {
+		return client.GetMessageOverrides()
+	})
+}
+
+func (c *authsignalClient) UpdateMessageOverrides(ctx context.Context, body authsignal.MessageOverridesBody) (*authsignal.MessageOverridesBody, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.MessageOverridesBody, int, error) {
+		return client.UpdateMessageOverrides(body)
+	})
+}
+
+func (c *authsignalClient) GetMessageOverridesCatalog(ctx context.Context) (*authsignal.MessageOverridesCatalog, int, error) {
+	return managementCall(ctx, c, func(client *authsignal.Client) (*authsignal.MessageOverridesCatalog, int, error) {
+		return client.GetMessageOverridesCatalog()
+	})
+}
+
+// managementCall makes one call of the Management API client for c, with ctx.
+func managementCall[T any](ctx context.Context, c *authsignalClient, call func(client *authsignal.Client) (*T, int, error)) (*T, int, error) {
+	response, _, statusCode, err := conditionalManagementCall(ctx, c, "", call)
+	return response, statusCode, err
+}
+
+// conditionalManagementCall is managementCall for a call whose requests are sent with ifMatch as
+// their If-Match header, when it is set. It also returns the ETag of the call's last response.
+func conditionalManagementCall[T any](ctx context.Context, c *authsignalClient, ifMatch string, call func(client *authsignal.Client) (*T, int, error)) (*T, string, int, error) {
+	relay, err := managementApiRelay()
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	if ifMatch != "" {
+		ctx = context.WithValue(ctx, ifMatchKey{}, ifMatch)
+	}
+
+	relayed := relay.register(c.requestContext(ctx), c)
+	defer relay.unregister(relayed)
+
+	client := authsignal.NewClient(relay.url+"/"+relayed.token, c.tenantId, c.apiSecret)
+	response, statusCode, err := call(&client)
+
+	// The relay's own record of the call is preferred to the client's, which sees a failure to reach
+	// the Management API only as a response from the relay.
+	if relayedStatusCode, relayedErr := relayed.result(); relayedErr != nil {
+		return nil, "", relayedStatusCode, relayedErr
+	}
+	if err != nil {
+		return nil, "", statusCode, err
+	}
+
+	return response, relayed.etag(), statusCode, nil
+}
+
+// ifMatchKey carries the ETag that a call's requests are sent with as If-Match.
+type ifMatchKey struct{}
+
+var (
+	relayOnce     sync.Once
+	relay         *managementRelay
+	relayStartErr error
+)
+
+// managementApiRelay returns the process's relay, starting it the first time.
+func managementApiRelay() (*managementRelay, error) {
+	relayOnce.Do(func() {
+		relay, relayStartErr = startManagementRelay()
+	})
+
+	return relay, relayStartErr
+}
+
+// managementRelay sends the requests of Management API client calls on to the Management API. Each call
+// is registered under a random token, which is the first segment of the paths the call's client
+// requests; a request under any other path is refused, so nothing else can use the relay.
+type managementRelay struct {
+	url string
+
+	mu    sync.Mutex
+	calls map[string]*relayedCall
+}
+
+// relayedCall is one Management API client call going through the relay.
+type relayedCall struct {
+	token  string
+	ctx    context.Context
+	client *authsignalClient
+
+	// statusCode, responseETag and err are those of the call's last request, set before the relay
+	// responds to it.
+	mu           sync.Mutex
+	statusCode   int
+	responseETag string
+	err          error
+}
+
+func (call *relayedCall) result() (int, error) {
+	call.mu.Lock()
+	defer call.mu.Unlock()
+
+	return call.statusCode, call.err
+}
+
+func (call *relayedCall) etag() string {
+	call.mu.Lock()
+	defer call.mu.Unlock()
+
+	return call.responseETag
+}
+
+func (call *relayedCall) setResult(statusCode int, etag string, err error) {
+	call.mu.Lock()
+	defer call.mu.Unlock()
+
+	call.statusCode = statusCode
+	call.responseETag = etag
+	call.err = err
+}
+
+func startManagementRelay() (*managementRelay, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("unable to start the Management API relay: %w", err)
+	}
+
+	r := &managementRelay{
+		url:   "http://" + listener.Addr().String(),
+		calls: map[string]*relayedCall{},
+	}
+
+	server := &http.Server{Handler: r, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = server.Serve(listener) }()
+
+	return r, nil
+}
+
+func (r *managementRelay) register(ctx context.Context, c *authsignalClient) *relayedCall {
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+
+	call := &relayedCall{token: hex.EncodeToString(token), ctx: ctx, client: c}
+
+	r.mu.Lock()
+	r.calls[call.token] = call
+	r.mu.Unlock()
+
+	return call
+}
+
+func (r *managementRelay) unregister(call *relayedCall) {
+	r.mu.Lock()
+	delete(r.calls, call.token)
+	r.mu.Unlock()
+}
+
+func (r *managementRelay) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	token, endpoint, _ := strings.Cut(strings.TrimPrefix(req.URL.EscapedPath(), "/"), "/")
+
+	r.mu.Lock()
+	call := r.calls[token]
+	r.mu.Unlock()
+
+	if call == nil {
+		http.NotFound(w, req)
+		return
+	}
+
+	call.forward(w, req, "/"+endpoint)
+}
+
+// forward sends req on to endpoint under the client's host, and writes the response back.
+func (call *relayedCall) forward(w http.ResponseWriter, req *http.Request, endpoint string) {
+	requestUrl := call.client.host + endpoint
+	if req.URL.RawQuery != "" {
+		requestUrl += "?" + req.URL.RawQuery
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		call.fail(w, err)
+		return
+	}
+
+	out, err := http.NewRequestWithContext(call.ctx, req.Method, requestUrl, bytes.NewReader(body))
+	if err != nil {
+		call.fail(w, err)
+		return
+	}
+	out.Header = req.Header.Clone()
+	// The transport of c.httpClient asks for and decompresses gzip itself.
+	out.Header.Del("Accept-Encoding")
+	out.Header.Del("Connection")
+	if ifMatch, ok := call.ctx.Value(ifMatchKey{}).(string); ok {
+		out.Header.Set("If-Match", ifMatch)
+	}
+
+	res, err := call.client.httpClient.Do(out)
+	if err != nil {
+		call.fail(w, err)
+		return
+	}
+	defer res.Body.Close()
+
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		call.fail(w, err)
+		return
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		call.setResult(res.StatusCode, "", newApiError(req.Method, endpoint, res, content))
+	} else {
+		call.setResult(res.StatusCode, res.Header.Get("ETag"), nil)
+	}
+
+	for name, values := range res.Header {
+		if name == "Content-Length" || name == "Content-Encoding" || name == "Connection" {
+			continue
+		}
+		w.Header()[name] = values
+	}
+	w.WriteHeader(res.StatusCode)
+	_, _ = w.Write(content)
+}
+
+// fail records that a request could not be sent or got no response, which the client sees as a 502.
+func (call *relayedCall) fail(w http.ResponseWriter, err error) {
+	call.setResult(0, "", err)
+
+	http.Error(w, err.Error(), http.StatusBadGateway)
+}
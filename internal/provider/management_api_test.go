@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type countingTransport struct {
+	next     http.RoundTripper
+	requests []string
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req.Method+" "+req.URL.Path)
+	return t.next.RoundTrip(req)
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type contextKey struct{}
+
+// relayRequest sends a request to the relay as the Management API client would for the call
+// registered under token.
+func relayRequest(t *testing.T, relay *managementRelay, token string, method string, endpoint string, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, relay.url+"/"+token+endpoint, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req.SetBasicAuth("secret", "")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+
+	return res
+}
+
+func TestManagementRelaySendsThroughTheClientsHttpClient(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		username, _, _ := r.BasicAuth()
+		received = append(received, r.Method+" "+r.URL.EscapedPath()+" "+username+" "+string(body))
+
+		_, _ = w.Write([]byte(`{"ruleId":"rule-1"}`))
+	}))
+	defer server.Close()
+
+	defaultTransport := http.DefaultTransport
+
+	relay, err := managementApiRelay()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first := &countingTransport{next: http.DefaultTransport}
+	second := &countingTransport{next: http.DefaultTransport}
+
+	firstClient := newAuthsignalClient(server.URL+"/v1/management", "tenant", "secret")
+	firstClient.httpClient = &http.Client{Transport: first}
+	secondClient := newAuthsignalClient(server.URL+"/v1/management", "tenant", "secret")
+	secondClient.httpClient = &http.Client{Transport: second}
+
+	relayed := relay.register(context.Background(), firstClient)
+	defer relay.unregister(relayed)
+
+	res := relayRequest(t, relay, relayed.token, http.MethodPatch, "/actions/sign%20in/rules/rule-1", `{"name":"block"}`)
+	content, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode != http.StatusOK || string(content) != `{"ruleId":"rule-1"}` {
+		t.Fatalf("unexpected response: %d %s", res.StatusCode, content)
+	}
+
+	expected := `PATCH /v1/management/actions/sign%20in/rules/rule-1 secret {"name":"block"}`
+	if len(received) != 1 || received[0] != expected {
+		t.Fatalf("bad requests received. expected: %v. got : %v", []string{expected}, received)
+	}
+
+	if len(first.requests) != 1 {
+		t.Fatalf("expected one request through the first client. got : %v", first.requests)
+	}
+
+	if len(second.requests) != 0 {
+		t.Fatalf("expected no requests through the second client. got : %v", second.requests)
+	}
+
+	if http.DefaultTransport != defaultTransport {
+		t.Fatalf("http.DefaultTransport was replaced")
+	}
+}
+
+func TestManagementRelaySendsWithTheCallsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	relay, err := managementApiRelay()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var value any
+	client := newAuthsignalClient(server.URL, "tenant", "secret")
+	client.httpClient = &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		value = req.Context().Value(contextKey{})
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+
+	relayed := relay.register(context.WithValue(context.Background(), contextKey{}, "resource"), client)
+	defer relay.unregister(relayed)
+
+	relayRequest(t, relay, relayed.token, http.MethodGet, "/tenant", "")
+
+	if value != "resource" {
+		t.Fatalf("expected the request to carry the call's context. got : %v", value)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cancelled := relay.register(ctx, client)
+	defer relay.unregister(cancelled)
+
+	res := relayRequest(t, relay, cancelled.token, http.MethodGet, "/tenant", "")
+	statusCode, err := cancelled.result()
+
+	if res.StatusCode != http.StatusBadGateway || statusCode != 0 || err == nil || !strings.Contains(err.Error(), "context canceled") {
+		t.Fatalf("expected a cancelled call to fail without a response. got : %d, %d, %v", res.StatusCode, statusCode, err)
+	}
+}
+
+func TestManagementRelayRecordsTheErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errorDescription":"Value list not found"}`))
+	}))
+	defer server.Close()
+
+	relay, err := managementApiRelay()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	relayed := relay.register(context.Background(), newAuthsignalClient(server.URL, "tenant", "secret"))
+	defer relay.unregister(relayed)
+
+	res := relayRequest(t, relay, relayed.token, http.MethodGet, "/value-lists/missing", "")
+	statusCode, err := relayed.result()
+
+	if res.StatusCode != http.StatusNotFound || statusCode != http.StatusNotFound || err == nil || !strings.Contains(err.Error(), "Value list not found") {
+		t.Fatalf("expected a 404 error. got : %d, %d, %v", res.StatusCode, statusCode, err)
+	}
+}
+
+func TestManagementRelayRefusesUnknownCalls(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { requests++ }))
+	defer server.Close()
+
+	relay, err := managementApiRelay()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	relayed := relay.register(context.Background(), newAuthsignalClient(server.URL, "tenant", "secret"))
+	relay.unregister(relayed)
+
+	res := relayRequest(t, relay, relayed.token, http.MethodGet, "/tenant", "")
+
+	if res.StatusCode != http.StatusNotFound || requests != 0 {
+		t.Fatalf("expected a finished call to be refused. got : %d, %d requests", res.StatusCode, requests)
+	}
+}
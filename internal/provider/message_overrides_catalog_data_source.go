@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/authsignal/authsignal-management-go/v6"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -21,13 +21,14 @@ func NewMessageOverridesCatalogDataSource() datasource.DataSource {
 }
 
 type messageOverridesCatalogDataSource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 type messageOverridesCatalogDataSourceModel struct {
 	CatalogVersion types.Int64                          `tfsdk:"catalog_version"`
 	Screens        []messageOverridesCatalogScreenModel `tfsdk:"screens"`
 	Points         []messageOverridesCatalogPointModel  `tfsdk:"points"`
+	Tenant         types.String                         `tfsdk:"tenant"`
 }
 
 type messageOverridesCatalogScreenModel struct {
@@ -57,6 +58,7 @@ func (d *messageOverridesCatalogDataSource) Schema(_ context.Context, _ datasour
 	resp.Schema = schema.Schema{
 		Description: "Retrieves the catalog of overridable pre-built UI message points, including their default copy, allowed placeholders and tags, and maximum length. Use it to discover valid `authsignal_message_overrides` IDs and locales.",
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantDataSourceAttribute(),
 			"catalog_version": schema.Int64Attribute{
 				Description: "The version of the message override catalog.",
 				Computed:    true,
@@ -129,7 +131,20 @@ func (d *messageOverridesCatalogDataSource) Schema(_ context.Context, _ datasour
 }
 
 func (d *messageOverridesCatalogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	catalog, _, err := d.client.GetMessageOverridesCatalog()
+	var tenant types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("tenant"), &tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.client.forTenant(tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	catalog, _, err := client.GetMessageOverridesCatalog(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to Read Authsignal Message Overrides Catalog", err.Error())
 		return
@@ -137,6 +152,7 @@ func (d *messageOverridesCatalogDataSource) Read(ctx context.Context, req dataso
 
 	state := messageOverridesCatalogDataSourceModel{
 		CatalogVersion: types.Int64Value(catalog.CatalogVersion),
+		Tenant:         tenant,
 	}
 
 	for _, screen := range catalog.Screens {
@@ -179,7 +195,7 @@ func (d *messageOverridesCatalogDataSource) Read(ctx context.Context, req dataso
 		})
 	}
 
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
@@ -190,12 +206,12 @@ func (d *messageOverridesCatalogDataSource) Configure(ctx context.Context, req d
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
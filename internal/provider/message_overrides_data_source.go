@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/authsignal/authsignal-management-go/v6"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -21,11 +21,12 @@ func NewMessageOverridesDataSource() datasource.DataSource {
 }
 
 type messageOverridesDataSource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 type messageOverridesDataSourceModel struct {
-	Overrides types.Map `tfsdk:"overrides"`
+	Overrides types.Map    `tfsdk:"overrides"`
+	Tenant    types.String `tfsdk:"tenant"`
 }
 
 func (d *messageOverridesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -36,6 +37,7 @@ func (d *messageOverridesDataSource) Schema(_ context.Context, _ datasource.Sche
 	resp.Schema = schema.Schema{
 		Description: "Retrieves a tenant's currently configured pre-built UI message overrides.",
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantDataSourceAttribute(),
 			"overrides": schema.MapAttribute{
 				Description: "Override copy keyed by locale (e.g. `en`, `pt-br`), then by message override ID (e.g. `sms-code-entry.heading`).",
 				ElementType: messageOverridesElemType,
@@ -46,7 +48,20 @@ func (d *messageOverridesDataSource) Schema(_ context.Context, _ datasource.Sche
 }
 
 func (d *messageOverridesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	messageOverrides, _, err := d.client.GetMessageOverrides()
+	var tenant types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("tenant"), &tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.client.forTenant(tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	messageOverrides, _, err := client.GetMessageOverrides(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to Read Authsignal Message Overrides", err.Error())
 		return
@@ -58,7 +73,7 @@ func (d *messageOverridesDataSource) Read(ctx context.Context, req datasource.Re
 		return
 	}
 
-	diags = resp.State.Set(ctx, messageOverridesDataSourceModel{Overrides: overridesValue})
+	diags = resp.State.Set(ctx, messageOverridesDataSourceModel{Overrides: overridesValue, Tenant: tenant})
 	resp.Diagnostics.Append(diags...)
 }
 
@@ -69,12 +84,12 @@ func (d *messageOverridesDataSource) Configure(ctx context.Context, req datasour
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
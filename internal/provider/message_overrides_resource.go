@@ -7,6 +7,7 @@ import (
 
 	"github.com/authsignal/authsignal-management-go/v6"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -22,16 +23,21 @@ var (
 // override ID to override copy, nested under a locale key.
 var messageOverridesElemType = types.MapType{ElemType: types.StringType}
 
+// messageOverridesApiFieldRenames maps the request body's `messageOverrides` to the `overrides`
+// attribute, for reporting errors on an override.
+var messageOverridesApiFieldRenames = map[string]string{"messageOverrides": "overrides"}
+
 func NewMessageOverridesResource() resource.Resource {
 	return &messageOverridesResource{}
 }
 
 type messageOverridesResource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 type messageOverridesResourceModel struct {
-	Overrides types.Map `tfsdk:"overrides"`
+	Overrides types.Map    `tfsdk:"overrides"`
+	Tenant    types.String `tfsdk:"tenant"`
 }
 
 func (r *messageOverridesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -42,6 +48,7 @@ func (r *messageOverridesResource) Schema(_ context.Context, _ resource.SchemaRe
 	resp.Schema = schema.Schema{
 		Description: "Manages a tenant's pre-built UI message overrides. This is a full-replacement, tenant-wide singleton: the configured value is the complete set of overrides, and applying removes any override not present. If the tenant already has overrides configured outside Terraform (e.g. in the admin portal), import the resource first (`terraform import authsignal_message_overrides.<name> \"\"`) instead of creating it, so the plan shows what will change. Use the `authsignal_message_overrides_catalog` data source to discover valid override IDs and locales.",
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantResourceAttribute(),
 			"overrides": schema.MapAttribute{
 				Description: "Override copy keyed by locale (e.g. `en`, `pt-br`), then by message override ID (e.g. `sms-code-entry.heading`). Omit to clear all overrides.",
 				ElementType: messageOverridesElemType,
@@ -68,6 +75,11 @@ func messageOverridesToMapValue(ctx context.Context, overrides map[string]map[st
 }
 
 func (r *messageOverridesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan messageOverridesResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -81,10 +93,16 @@ func (r *messageOverridesResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Message overrides are a tenant-wide singleton, so a create is a full replacement. Guard against
 	// silently wiping overrides configured outside Terraform (e.g. in the admin portal): if the tenant
 	// already has overrides that differ from the plan, require an import first so the plan shows the diff.
-	existing, _, err := r.client.GetMessageOverrides()
+	existing, _, err := client.GetMessageOverrides(withFreshReads(ctx))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Authsignal Message Overrides",
@@ -103,12 +121,14 @@ func (r *messageOverridesResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	_, _, err = r.client.UpdateMessageOverrides(authsignal.MessageOverridesBody{MessageOverrides: overrides})
+	_, _, err = client.UpdateMessageOverrides(ctx, authsignal.MessageOverridesBody{MessageOverrides: overrides})
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error creating message overrides",
-			"Could not create message overrides, unexpected error: "+err.Error(),
-		)
+			"Could not create message overrides",
+			err,
+			resourceApiErrorFields(ctx, r, messageOverridesApiFieldRenames),
+		)...)
 		return
 	}
 
@@ -120,7 +140,20 @@ func (r *messageOverridesResource) Create(ctx context.Context, req resource.Crea
 }
 
 func (r *messageOverridesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	messageOverrides, statusCode, err := r.client.GetMessageOverrides()
+	var tenant types.String
+	diags := req.State.GetAttribute(ctx, path.Root("tenant"), &tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.forTenant(tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	messageOverrides, statusCode, err := client.GetMessageOverrides(ctx)
 
 	if statusCode == 404 {
 		resp.State.RemoveResource(ctx)
@@ -141,7 +174,7 @@ func (r *messageOverridesResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	diags = resp.State.Set(ctx, messageOverridesResourceModel{Overrides: overridesValue})
+	diags = resp.State.Set(ctx, messageOverridesResourceModel{Overrides: overridesValue, Tenant: tenant})
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -149,6 +182,11 @@ func (r *messageOverridesResource) Read(ctx context.Context, req resource.ReadRe
 }
 
 func (r *messageOverridesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan messageOverridesResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -162,12 +200,20 @@ func (r *messageOverridesResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	_, _, err := r.client.UpdateMessageOverrides(authsignal.MessageOverridesBody{MessageOverrides: overrides})
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, _, err := client.UpdateMessageOverrides(ctx, authsignal.MessageOverridesBody{MessageOverrides: overrides})
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error updating message overrides",
-			"Could not update message overrides, unexpected error: "+err.Error(),
-		)
+			"Could not update message overrides",
+			err,
+			resourceApiErrorFields(ctx, r, messageOverridesApiFieldRenames),
+		)...)
 		return
 	}
 
@@ -179,20 +225,42 @@ func (r *messageOverridesResource) Update(ctx context.Context, req resource.Upda
 }
 
 func (r *messageOverridesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	_, _, err := r.client.UpdateMessageOverrides(authsignal.MessageOverridesBody{MessageOverrides: map[string]map[string]string{}})
+	resp.Diagnostics.Append(r.client.checkWritable("delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tenant types.String
+	diags := req.State.GetAttribute(ctx, path.Root("tenant"), &tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.forTenant(tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, _, err := client.UpdateMessageOverrides(ctx, authsignal.MessageOverridesBody{MessageOverrides: map[string]map[string]string{}})
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error Deleting Authsignal Message Overrides",
-			"Could not delete message overrides, unexpected error: "+err.Error(),
-		)
+			"Could not delete message overrides",
+			err,
+			resourceApiErrorFields(ctx, r, messageOverridesApiFieldRenames),
+		)...)
 		return
 	}
 }
 
 func (r *messageOverridesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Message overrides are a tenant-wide singleton keyed by the API secret, so there is nothing to
-	// key the import on. Seed empty state; the subsequent Read populates it from the API.
-	resp.Diagnostics.Append(resp.State.Set(ctx, messageOverridesResourceModel{Overrides: types.MapNull(messageOverridesElemType)})...)
+	// Message overrides are a tenant-wide singleton keyed by the API secret, so the import ID is empty,
+	// or names one of the provider's tenants as `<tenant>:`. Seed empty state; the subsequent Read
+	// populates it from the API.
+	tenant, _ := r.client.splitTenantImportId(req.ID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, messageOverridesResourceModel{Overrides: types.MapNull(messageOverridesElemType), Tenant: tenant})...)
 }
 
 func (r *messageOverridesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -200,12 +268,12 @@ func (r *messageOverridesResource) Configure(_ context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
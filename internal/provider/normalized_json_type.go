@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = normalizedJsonType{}
+	_ xattr.TypeWithValidate                     = normalizedJsonType{}
+	_ basetypes.StringValuableWithSemanticEquals = normalizedJsonValue{}
+)
+
+// normalizedJsonType is a string attribute holding a JSON document. Two values are equal when they
+// decode to the same document, so key order, whitespace, escaping and number formatting differences
+// between what was configured and what the API hands back never show up as a diff.
+type normalizedJsonType struct {
+	basetypes.StringType
+}
+
+func (t normalizedJsonType) Equal(o attr.Type) bool {
+	other, ok := o.(normalizedJsonType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t normalizedJsonType) String() string {
+	return "normalizedJsonType"
+}
+
+func (t normalizedJsonType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return normalizedJsonValue{StringValue: in}, nil
+}
+
+func (t normalizedJsonType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return normalizedJsonValue{StringValue: stringValue}, nil
+}
+
+func (t normalizedJsonType) ValueType(_ context.Context) attr.Value {
+	return normalizedJsonValue{}
+}
+
+func (t normalizedJsonType) Validate(_ context.Context, in tftypes.Value, valuePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !in.IsKnown() || in.IsNull() {
+		return diags
+	}
+
+	var value string
+	if err := in.As(&value); err != nil {
+		diags.AddAttributeError(valuePath, "Invalid JSON String Value", err.Error())
+		return diags
+	}
+
+	if !json.Valid([]byte(value)) {
+		diags.AddAttributeError(
+			valuePath,
+			"Invalid JSON String Value",
+			fmt.Sprintf("A string value was provided that is not valid JSON. Use jsonencode() to build the value.\n\nGiven Value: %s", value),
+		)
+	}
+
+	return diags
+}
+
+type normalizedJsonValue struct {
+	basetypes.StringValue
+}
+
+func normalizedJsonValueFrom(value string) normalizedJsonValue {
+	return normalizedJsonValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func normalizedJsonNull() normalizedJsonValue {
+	return normalizedJsonValue{StringValue: basetypes.NewStringNull()}
+}
+
+func (v normalizedJsonValue) Equal(o attr.Value) bool {
+	other, ok := o.(normalizedJsonValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v normalizedJsonValue) Type(_ context.Context) attr.Type {
+	return normalizedJsonType{}
+}
+
+func (v normalizedJsonValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(normalizedJsonValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return jsonSemanticallyEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+// jsonSemanticallyEqual reports whether two JSON strings decode to the same document. Invalid JSON
+// is never equal to anything, so it is left for validation to report.
+func jsonSemanticallyEqual(a string, b string) bool {
+	var aDocument, bDocument any
+
+	if err := json.Unmarshal([]byte(a), &aDocument); err != nil {
+		return false
+	}
+
+	if err := json.Unmarshal([]byte(b), &bDocument); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(aDocument, bDocument)
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNormalizedJsonIgnoresFormatting(t *testing.T) {
+	testCases := []struct {
+		name          string
+		prior         string
+		proposed      string
+		expectedEqual bool
+	}{
+		{name: "key order", prior: `{"a":1,"b":2}`, proposed: `{"b":2,"a":1}`, expectedEqual: true},
+		{name: "whitespace", prior: `{"and":[{"==":[{"var":"ip.isAnonymous"},false]}]}`, proposed: "{\n  \"and\": [ { \"==\": [ { \"var\": \"ip.isAnonymous\" }, false ] } ]\n}", expectedEqual: true},
+		{name: "escaping", prior: `{"\u003e":[{"var":"custom.riskScore"},80]}`, proposed: `{">":[{"var":"custom.riskScore"},80]}`, expectedEqual: true},
+		{name: "number formatting", prior: `{"==":[{"var":"custom.riskScore"},80]}`, proposed: `{"==":[{"var":"custom.riskScore"},80.0]}`, expectedEqual: true},
+		{name: "different value", prior: `{"==":[{"var":"ip.isAnonymous"},false]}`, proposed: `{"==":[{"var":"ip.isAnonymous"},true]}`, expectedEqual: false},
+		{name: "array order", prior: `{"and":[1,2]}`, proposed: `{"and":[2,1]}`, expectedEqual: false},
+		{name: "invalid json", prior: `{"a":1}`, proposed: `{"a":1`, expectedEqual: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			equal, diags := normalizedJsonValueFrom(testCase.prior).StringSemanticEquals(context.Background(), normalizedJsonValueFrom(testCase.proposed))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if equal != testCase.expectedEqual {
+				t.Fatalf("bad semantic equality. expected: %v. got : %v", testCase.expectedEqual, equal)
+			}
+		})
+	}
+}
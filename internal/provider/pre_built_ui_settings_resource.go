@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/authsignal/authsignal-management-go/v6"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -21,11 +22,12 @@ func NewPreBuiltUiSettingsResource() resource.Resource {
 }
 
 type preBuiltUiSettingsResource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 type preBuiltUiSettingsResourceModel struct {
-	HideSuccessScreenOnEnrollment types.Bool `tfsdk:"hide_success_screen_on_enrollment"`
+	HideSuccessScreenOnEnrollment types.Bool   `tfsdk:"hide_success_screen_on_enrollment"`
+	Tenant                        types.String `tfsdk:"tenant"`
 }
 
 func (r *preBuiltUiSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -36,6 +38,7 @@ func (r *preBuiltUiSettingsResource) Schema(_ context.Context, _ resource.Schema
 	resp.Schema = schema.Schema{
 		Description: "Manages a tenant's pre-built UI settings. The tenant itself already exists and cannot be created or deleted through this API, so this resource only ever updates settings. Applying it changes the settings configured here and leaves the rest of the tenant untouched. Destroying it stops Terraform managing those settings rather than resetting them. A setting left out of the configuration stays unmanaged, so it can still be set in the admin portal.",
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantResourceAttribute(),
 			"hide_success_screen_on_enrollment": schema.BoolAttribute{
 				Description: "Whether the pre-built UI skips the success screen shown after a user enrolls an authenticator.",
 				Optional:    true,
@@ -57,13 +60,19 @@ func tenantSettingsFromModel(model preBuiltUiSettingsResourceModel) authsignal.T
 	return settings
 }
 
-func preBuiltUiSettingsModelFromResponse(tenant *authsignal.TenantResponse) preBuiltUiSettingsResourceModel {
+func preBuiltUiSettingsModelFromResponse(tenant *authsignal.TenantResponse, tenantKey types.String) preBuiltUiSettingsResourceModel {
 	return preBuiltUiSettingsResourceModel{
 		HideSuccessScreenOnEnrollment: types.BoolPointerValue(tenant.HideSuccessScreenOnEnrollment),
+		Tenant:                        tenantKey,
 	}
 }
 
 func (r *preBuiltUiSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var config preBuiltUiSettingsResourceModel
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
@@ -71,21 +80,42 @@ func (r *preBuiltUiSettingsResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
-	tenant, _, err := r.client.UpdateTenant(tenantSettingsFromModel(config))
+	client, diags := r.client.forTenant(config.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenant, _, err := client.UpdateTenant(ctx, tenantSettingsFromModel(config))
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error creating pre-built UI settings",
-			"Could not create pre-built UI settings, unexpected error: "+err.Error(),
-		)
+			"Could not create pre-built UI settings",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 
-	diags = resp.State.Set(ctx, preBuiltUiSettingsModelFromResponse(tenant))
+	diags = resp.State.Set(ctx, preBuiltUiSettingsModelFromResponse(tenant, config.Tenant))
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *preBuiltUiSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	tenant, statusCode, err := r.client.GetTenant()
+	var tenantKey types.String
+	diags := req.State.GetAttribute(ctx, path.Root("tenant"), &tenantKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.forTenant(tenantKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenant, statusCode, err := client.GetTenant(ctx)
 
 	if statusCode == 404 {
 		resp.State.RemoveResource(ctx)
@@ -100,11 +130,16 @@ func (r *preBuiltUiSettingsResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
-	diags := resp.State.Set(ctx, preBuiltUiSettingsModelFromResponse(tenant))
+	diags = resp.State.Set(ctx, preBuiltUiSettingsModelFromResponse(tenant, tenantKey))
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *preBuiltUiSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var config preBuiltUiSettingsResourceModel
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
@@ -112,16 +147,24 @@ func (r *preBuiltUiSettingsResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
-	tenant, _, err := r.client.UpdateTenant(tenantSettingsFromModel(config))
+	client, diags := r.client.forTenant(config.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenant, _, err := client.UpdateTenant(ctx, tenantSettingsFromModel(config))
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error updating pre-built UI settings",
-			"Could not update pre-built UI settings, unexpected error: "+err.Error(),
-		)
+			"Could not update pre-built UI settings",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 
-	diags = resp.State.Set(ctx, preBuiltUiSettingsModelFromResponse(tenant))
+	diags = resp.State.Set(ctx, preBuiltUiSettingsModelFromResponse(tenant, config.Tenant))
 	resp.Diagnostics.Append(diags...)
 }
 
@@ -132,9 +175,10 @@ func (r *preBuiltUiSettingsResource) Delete(_ context.Context, _ resource.Delete
 }
 
 func (r *preBuiltUiSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// The provider is configured against a single tenant, so there is nothing to key the import on.
-	// Seed empty state; the subsequent Read populates it from the API.
-	resp.Diagnostics.Append(resp.State.Set(ctx, preBuiltUiSettingsResourceModel{})...)
+	// The settings belong to the tenant itself, so the import ID is empty, or names one of the
+	// provider's tenants as `<tenant>:`. Seed empty state; the subsequent Read populates it from the API.
+	tenant, _ := r.client.splitTenantImportId(req.ID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, preBuiltUiSettingsResourceModel{Tenant: tenant})...)
 }
 
 func (r *preBuiltUiSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -142,12 +186,12 @@ func (r *preBuiltUiSettingsResource) Configure(_ context.Context, req resource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
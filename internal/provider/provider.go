@@ -2,22 +2,37 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
+	"time"
 
-	"github.com/authsignal/authsignal-management-go/v6"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
-	_ provider.Provider = &authsignalProvider{}
+	_ provider.Provider              = &authsignalProvider{}
+	_ provider.ProviderWithFunctions = &authsignalProvider{}
 )
 
+// durationPattern matches the durations time.ParseDuration accepts, such as `30s` or `1m30s`.
+var durationPattern = regexp.MustCompile(`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`)
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &authsignalProvider{
@@ -31,7 +46,31 @@ type authsignalProvider struct {
 }
 
 type authsignalProviderModel struct {
+	Host                  types.String `tfsdk:"host"`
+	Region                types.String `tfsdk:"region"`
+	TenantID              types.String `tfsdk:"tenant_id"`
+	ApiSecret             types.String `tfsdk:"api_secret"`
+	ApiSecretFile         types.String `tfsdk:"api_secret_file"`
+	ApiSecretCommand      types.List   `tfsdk:"api_secret_command"`
+	Profile               types.String `tfsdk:"profile"`
+	CredentialsFile       types.String `tfsdk:"credentials_file"`
+	StrictRulePriorities  types.Bool   `tfsdk:"strict_rule_priorities"`
+	MaxRetries            types.Int64  `tfsdk:"max_retries"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	RetryMaxWait          types.String `tfsdk:"retry_max_wait"`
+	HttpProxy             types.String `tfsdk:"http_proxy"`
+	CaCertFile            types.String `tfsdk:"ca_cert_file"`
+	CaCertPem             types.String `tfsdk:"ca_cert_pem"`
+	InsecureSkipVerify    types.Bool   `tfsdk:"insecure_skip_verify"`
+	RequestTimeout        types.String `tfsdk:"request_timeout"`
+	Tenants               types.Map    `tfsdk:"tenants"`
+	VerifyCredentials     types.Bool   `tfsdk:"verify_credentials"`
+	ReadOnly              types.Bool   `tfsdk:"read_only"`
+}
+
+type authsignalProviderTenantModel struct {
 	Host      types.String `tfsdk:"host"`
+	Region    types.String `tfsdk:"region"`
 	TenantID  types.String `tfsdk:"tenant_id"`
 	ApiSecret types.String `tfsdk:"api_secret"`
 }
@@ -45,17 +84,139 @@ func (p *authsignalProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Description: "The host URL of the Authsignal Management API for your tenant.",
+				Description: "The host URL of the Authsignal Management API for your tenant, such as `https://api.authsignal.com/v1/management`. Can also be set with the AUTHSIGNAL_HOST environment variable or in the credentials file. Conflicts with `region`.",
 				Optional:    true,
 			},
+			"region": schema.StringAttribute{
+				Description: "The Authsignal region your tenant is in, which determines the host: `us`, `au`, `eu` or `ca`. Can also be set with the AUTHSIGNAL_REGION environment variable or in the credentials file. Conflicts with `host`; when one is set in the configuration and the other in the environment, the configuration wins.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(sortedKeys(regionHosts)...),
+					stringvalidator.ConflictsWith(path.MatchRoot("host")),
+				},
+			},
 			"tenant_id": schema.StringAttribute{
-				Description: "The ID of your tenant.",
+				Description: "The ID of your tenant. Can also be set with the AUTHSIGNAL_TENANT_ID environment variable or in the credentials file.",
 				Optional:    true,
 			},
 			"api_secret": schema.StringAttribute{
-				Description: "The Management API Secret obtained from Authsignal's admin portal.",
+				Description: "The Management API Secret obtained from Authsignal's admin portal. Can also be set with the AUTHSIGNAL_API_SECRET environment variable or in the credentials file. Conflicts with `api_secret_file` and `api_secret_command`.",
 				Optional:    true,
 				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("api_secret_file"), path.MatchRoot("api_secret_command")),
+				},
+			},
+			"api_secret_file": schema.StringAttribute{
+				Description: "The path to a file holding the Management API Secret, such as one mounted from a secrets store. Surrounding whitespace is ignored. Can also be set with the AUTHSIGNAL_API_SECRET_FILE environment variable or in the credentials file. Conflicts with `api_secret_command`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("api_secret_command")),
+				},
+			},
+			"api_secret_command": schema.ListAttribute{
+				Description: "A command that prints the Management API Secret, as the program followed by its arguments, such as `[\"vault\", \"kv\", \"get\", \"-field=api_secret\", \"secret/authsignal\"]`. " +
+					"It may print the bare secret, or a JSON object such as `{\"api_secret\": \"...\", \"expires_at\": \"2030-01-01T00:00:00Z\"}`, in which case an expired secret is an error. " +
+					"The command runs each time the provider is configured, and must finish within a minute. " +
+					"Can also be set with the AUTHSIGNAL_API_SECRET_COMMAND environment variable or in the credentials file, as a command line whose arguments are quoted as in a shell, such as `vault kv get -field=api_secret 'secret/authsignal prod'`. The command is not run by a shell.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"profile": schema.StringAttribute{
+				Description: "The profile of the credentials file to take host, region, tenant_id and api_secret from, for those not set in the configuration or the environment. Can also be set with the AUTHSIGNAL_PROFILE environment variable. Defaults to the `default` profile, if the file has one.",
+				Optional:    true,
+			},
+			"credentials_file": schema.StringAttribute{
+				Description: "The path to the credentials file. Can also be set with the AUTHSIGNAL_CREDENTIALS_FILE environment variable. Defaults to `~/.authsignal/credentials`.",
+				Optional:    true,
+			},
+			"strict_rule_priorities": schema.BoolAttribute{
+				Description: "Whether two rules planned on the same action with the same priority fail the plan. By default they are reported as a warning. Rules already on the tenant that are not in the configuration are not checked, as the Management API client cannot list them yet.",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "How many times a request to the Management API is retried after a rate limit (429), a server error (5xx) or a network error. Creates, updates and deletes are only retried after a rate limit or a failure to connect, as they may otherwise have taken effect already. Defaults to 3. Set to 0 to disable retries.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "The most requests to the Management API in flight at once, across every resource and data source, whatever Terraform's `-parallelism`. Requests beyond it wait for one to finish. Lower it if applies trip the tenant's rate limits. By default there is no limit.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Description: "The longest to wait before retrying a request, as a duration such as `30s` or `2m`. Waits back off exponentially with jitter up to this, unless the API asks for a specific wait with a `Retry-After` header. A request is not retried if the API asks for a longer wait than this. Defaults to `30s`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(durationPattern, "must be a duration such as `30s` or `2m`"),
+				},
+			},
+			"http_proxy": schema.StringAttribute{
+				Description: "The URL of a proxy to send requests to the Management API through, such as `http://proxy.example.com:3128`. Can also be set with the AUTHSIGNAL_HTTP_PROXY environment variable. When neither is set, the standard HTTPS_PROXY and NO_PROXY environment variables apply.",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "The path to a PEM encoded CA certificate bundle to trust, in addition to the system's, such as that of a TLS-inspecting proxy. Can also be set with the AUTHSIGNAL_CA_CERT_FILE environment variable.",
+				Optional:    true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "A PEM encoded CA certificate bundle to trust, in addition to the system's. Can also be set with the AUTHSIGNAL_CA_CERT_PEM environment variable.",
+				Optional:    true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Whether to skip verifying the Management API's TLS certificate. This lets anyone on the network read and change requests, including the API secret, so it should only be used for local testing. Prefer `ca_cert_file` or `ca_cert_pem`. Can also be set with the AUTHSIGNAL_INSECURE_SKIP_VERIFY environment variable.",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "The longest a single request to the Management API may take, as a duration such as `30s`. Each retry gets the full timeout again. Can also be set with the AUTHSIGNAL_REQUEST_TIMEOUT environment variable. By default requests have no timeout.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(durationPattern, "must be a duration such as `30s` or `2m`"),
+				},
+			},
+			"verify_credentials": schema.BoolAttribute{
+				Description: "Whether to check the credentials of every tenant with a request to the Management API when the provider is configured, so that a wrong host, tenant_id or api_secret fails the plan before any resource is changed. Can also be set with the AUTHSIGNAL_VERIFY_CREDENTIALS environment variable. Defaults to `false`.",
+				Optional:    true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Whether to stop resources from changing any tenant. Data sources, refreshing state and planning work as usual, but applying a create, update or delete fails before anything is sent to the Management API. Use it to detect drift on a production tenant with its real credentials. Can also be set with the AUTHSIGNAL_READ_ONLY environment variable. Defaults to `false`.",
+				Optional:    true,
+			},
+			"tenants": schema.MapNestedAttribute{
+				Description: "Other tenants this provider manages, by a key of your choosing. Resources and data sources select one with their `tenant` attribute, and otherwise use the tenant configured by `host`, `tenant_id` and `api_secret`. When `tenants` is set, those may be left unset, and every resource must then select a tenant.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"host": schema.StringAttribute{
+							Description: "The host URL of the Authsignal Management API for the tenant. Defaults to the provider's host. Conflicts with `region`.",
+							Optional:    true,
+						},
+						"region": schema.StringAttribute{
+							Description: "The Authsignal region the tenant is in, which determines the host: `us`, `au`, `eu` or `ca`. Conflicts with `host`.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(sortedKeys(regionHosts)...),
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("host")),
+							},
+						},
+						"tenant_id": schema.StringAttribute{
+							Description: "The ID of the tenant.",
+							Required:    true,
+						},
+						"api_secret": schema.StringAttribute{
+							Description: "The Management API Secret of the tenant.",
+							Required:    true,
+							Sensitive:   true,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -78,6 +239,15 @@ func (p *authsignalProvider) Configure(ctx context.Context, req provider.Configu
 		)
 	}
 
+	if config.Region.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("region"),
+			"Unknown Authsignal Region",
+			"The provider cannot create the Authsignal API client as there is an unknown configuration value for the Authsignal region. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the AUTHSIGNAL_REGION environment variable.",
+		)
+	}
+
 	if config.TenantID.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("tenant_id"),
@@ -96,53 +266,159 @@ func (p *authsignalProvider) Configure(ctx context.Context, req provider.Configu
 		)
 	}
 
+	if config.ApiSecretFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_secret_file"),
+			"Unknown Authsignal API Secret File",
+			"The provider cannot create the Authsignal API client as there is an unknown configuration value for the Authsignal API Secret file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the AUTHSIGNAL_API_SECRET_FILE environment variable.",
+		)
+	}
+
+	if config.ApiSecretCommand.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_secret_command"),
+			"Unknown Authsignal API Secret Command",
+			"The provider cannot create the Authsignal API client as there is an unknown configuration value for the Authsignal API Secret command. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the AUTHSIGNAL_API_SECRET_COMMAND environment variable.",
+		)
+	}
+
+	if config.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown Authsignal Credentials Profile",
+			"The provider cannot create the Authsignal API client as there is an unknown configuration value for the credentials profile. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the AUTHSIGNAL_PROFILE environment variable.",
+		)
+	}
+
+	if config.CredentialsFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credentials_file"),
+			"Unknown Authsignal Credentials File",
+			"The provider cannot create the Authsignal API client as there is an unknown configuration value for the credentials file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the AUTHSIGNAL_CREDENTIALS_FILE environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	host := os.Getenv("AUTHSIGNAL_HOST")
-	tenant_id := os.Getenv("AUTHSIGNAL_TENANT_ID")
-	api_secret := os.Getenv("AUTHSIGNAL_API_SECRET")
-
-	if !config.Host.IsNull() {
-		host = config.Host.ValueString()
+	profile, diags := loadCredentialsProfile(
+		stringConfigValue(config.CredentialsFile, "AUTHSIGNAL_CREDENTIALS_FILE"),
+		stringConfigValue(config.Profile, "AUTHSIGNAL_PROFILE"),
+	)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	tenant_id := os.Getenv("AUTHSIGNAL_TENANT_ID")
+
 	if !config.TenantID.IsNull() {
 		tenant_id = config.TenantID.ValueString()
 	}
 
-	if !config.ApiSecret.IsNull() {
-		api_secret = config.ApiSecret.ValueString()
+	var apiSecretCommand []string
+	resp.Diagnostics.Append(config.ApiSecretCommand.ElementsAs(ctx, &apiSecretCommand, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API secret comes from the configuration, else the environment, else the profile. Each may
+	// give it as the secret itself, a file or a command.
+	apiSecretSources := []apiSecretSource{
+		{value: config.ApiSecret.ValueString(), file: config.ApiSecretFile.ValueString(), command: apiSecretCommand, path: path.Root("api_secret")},
+		{value: os.Getenv("AUTHSIGNAL_API_SECRET"), path: path.Root("api_secret")},
+		{file: os.Getenv("AUTHSIGNAL_API_SECRET_FILE"), path: path.Root("api_secret_file")},
+		{commandLine: os.Getenv("AUTHSIGNAL_API_SECRET_COMMAND"), path: path.Root("api_secret_command")},
+	}
+	if profile != nil {
+		apiSecretSources = append(apiSecretSources, apiSecretSource{
+			value:       profile.ApiSecret,
+			file:        profile.ApiSecretFile,
+			commandLine: profile.ApiSecretCommand,
+			path:        path.Root("profile"),
+		})
 	}
 
-	if host == "" {
+	api_secret := ""
+	for _, source := range apiSecretSources {
+		if source.isSet() {
+			api_secret, diags = resolveApiSecret(ctx, source)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			break
+		}
+	}
+
+	host, diags := resolveHost(
+		hostSetting{value: stringConfigValue(config.Host, "AUTHSIGNAL_HOST"), fromConfig: !config.Host.IsNull(), path: path.Root("host")},
+		hostSetting{value: stringConfigValue(config.Region, "AUTHSIGNAL_REGION"), fromConfig: !config.Region.IsNull(), path: path.Root("region")},
+	)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The credentials profile fills in whatever the configuration and the environment leave unset.
+	if profile != nil {
+		if host == "" {
+			host, diags = resolveHost(
+				hostSetting{value: profile.Host, path: path.Root("profile")},
+				hostSetting{value: profile.Region, path: path.Root("profile")},
+			)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		if tenant_id == "" {
+			tenant_id = profile.TenantID
+		}
+	}
+
+	tenants, diags := configureTenants(ctx, config.Tenants, host)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// With other tenants configured, the provider needs no tenant of its own.
+	hasDefaultTenant := len(tenants) == 0 || tenant_id != "" || api_secret != ""
+
+	if host == "" && hasDefaultTenant {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("host"),
 			"Missing Authsignal API Host",
 			"The provider cannot create the Authsignal API client as there is a missing or empty value for the Authsignal API host. "+
-				"Set the host value in the configuration or use the AUTHSIGNAL_HOST environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the host or region value in the configuration or use the AUTHSIGNAL_HOST or AUTHSIGNAL_REGION environment variable, or set it in a profile of the credentials file. "+
+				"If any is already set, ensure the value is not empty.",
 		)
 	}
 
-	if tenant_id == "" {
+	if tenant_id == "" && hasDefaultTenant {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("tenant_id"),
 			"Missing Authsignal API Tenant ID",
 			"The provider cannot create the Authsignal API client as there is a missing or empty value for the Authsignal API Tenant ID. "+
-				"Set the tenant_id value in the configuration or use the AUTHSIGNAL_TENANT_ID environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the tenant_id value in the configuration or use the AUTHSIGNAL_TENANT_ID environment variable, or set it in a profile of the credentials file. "+
+				"If any is already set, ensure the value is not empty.",
 		)
 	}
 
-	if api_secret == "" {
+	if api_secret == "" && hasDefaultTenant {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_secret"),
 			"Missing Authsignal API Secret",
 			"The provider cannot create the Authsignal API client as there is a missing or empty value for the Authsignal API Secret. "+
-				"Set the api_secret value in the configuration or use the AUTHSIGNAL_API_SECRET environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the api_secret value in the configuration or use the AUTHSIGNAL_API_SECRET environment variable, or set it in a profile of the credentials file. "+
+				"If any is already set, ensure the value is not empty.",
 		)
 	}
 
@@ -150,17 +426,279 @@ func (p *authsignalProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
+	// No log of the provider's configuration from here on shows an API secret. The requests to the
+	// Management API are logged through the context of each call, masked by requestContext.
+	apiSecrets := []string{}
+	if api_secret != "" {
+		apiSecrets = append(apiSecrets, api_secret)
+	}
+	for _, tenant := range tenants {
+		apiSecrets = append(apiSecrets, tenant.ApiSecret.ValueString())
+	}
+	ctx = tflog.MaskLogStrings(ctx, apiSecrets...)
+
+	transport, diags := configureTransport(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	ctx = tflog.SetField(ctx, "authsignal_host", host)
 	ctx = tflog.SetField(ctx, "authsignal_tenant_id", tenant_id)
 
-	client := authsignal.NewClient(host, tenant_id, api_secret)
+	httpClient := &http.Client{Transport: transport}
+	strictRulePriorities := config.StrictRulePriorities.ValueBool()
+
+	readOnly, diags := boolConfigValue(config.ReadOnly, "AUTHSIGNAL_READ_ONLY", path.Root("read_only"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := &authsignalClient{host: host}
+	if hasDefaultTenant {
+		client = newAuthsignalClient(host, tenant_id, api_secret)
+	}
+	client.httpClient = httpClient
+	client.strictRulePriorities = strictRulePriorities
+	client.readOnly = readOnly
+
+	client.tenants = map[string]*authsignalClient{}
+	for key, tenant := range tenants {
+		tenantClient := newAuthsignalClient(tenant.Host.ValueString(), tenant.TenantID.ValueString(), tenant.ApiSecret.ValueString())
+		tenantClient.httpClient = httpClient
+		tenantClient.strictRulePriorities = strictRulePriorities
+		tenantClient.readOnly = readOnly
+		client.tenants[key] = tenantClient
+	}
+
+	verifyCredentials, diags := boolConfigValue(config.VerifyCredentials, "AUTHSIGNAL_VERIFY_CREDENTIALS", path.Root("verify_credentials"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if verifyCredentials {
+		if client.hasOwnTenant() {
+			resp.Diagnostics.Append(client.verifyCredentials(ctx, path.Empty())...)
+		}
 
-	resp.DataSourceData = &client
-	resp.ResourceData = &client
+		for _, key := range sortedKeys(client.tenants) {
+			resp.Diagnostics.Append(client.tenants[key].verifyCredentials(ctx, path.Root("tenants").AtMapKey(key))...)
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.DataSourceData = client
+	resp.ResourceData = client
 
 	tflog.Info(ctx, "Configured Authsignal client", map[string]any{"success": true})
 }
 
+// configureTenants reads the provider's `tenants`, with each tenant's host defaulted to the provider's.
+func configureTenants(ctx context.Context, value types.Map, defaultHost string) (map[string]authsignalProviderTenantModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	tenants := map[string]authsignalProviderTenantModel{}
+
+	if value.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("tenants"),
+			"Unknown Authsignal Tenants",
+			"The provider cannot create the Authsignal API clients as the tenants are not known until apply. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+		return nil, diags
+	}
+
+	if value.IsNull() {
+		return tenants, diags
+	}
+
+	diags.Append(value.ElementsAs(ctx, &tenants, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for key, tenant := range tenants {
+		tenantPath := path.Root("tenants").AtMapKey(key)
+
+		if tenant.Host.IsUnknown() || tenant.Region.IsUnknown() || tenant.TenantID.IsUnknown() || tenant.ApiSecret.IsUnknown() {
+			diags.AddAttributeError(
+				tenantPath,
+				"Unknown Authsignal Tenant",
+				fmt.Sprintf("The provider cannot create the Authsignal API client for tenant %q as its host, region, tenant_id or api_secret is not known until apply. ", key)+
+					"Either target apply the source of the value first, or set the value statically in the configuration.",
+			)
+			continue
+		}
+
+		host, hostDiags := resolveHost(
+			hostSetting{value: tenant.Host.ValueString(), fromConfig: true, path: tenantPath.AtName("host")},
+			hostSetting{value: tenant.Region.ValueString(), fromConfig: true, path: tenantPath.AtName("region")},
+		)
+		diags.Append(hostDiags...)
+		if hostDiags.HasError() {
+			continue
+		}
+
+		if host == "" {
+			host = defaultHost
+		}
+		tenant.Host = types.StringValue(host)
+
+		if tenant.Host.ValueString() == "" {
+			diags.AddAttributeError(
+				tenantPath.AtName("host"),
+				"Missing Authsignal API Host",
+				fmt.Sprintf("The provider cannot create the Authsignal API client for tenant %q as there is no host for it. ", key)+
+					"Set its host or region, or the provider's host or region.",
+			)
+		}
+
+		if tenant.TenantID.ValueString() == "" || tenant.ApiSecret.ValueString() == "" {
+			diags.AddAttributeError(
+				tenantPath,
+				"Missing Authsignal Tenant Credentials",
+				fmt.Sprintf("The tenant_id and api_secret of tenant %q must not be empty.", key),
+			)
+		}
+
+		tenants[key] = tenant
+	}
+
+	return tenants, diags
+}
+
+// configureTransport builds the chain of transports requests to the Management API go through: the
+// read cache, then retries, then the concurrency limit, then logging, then the per-request
+// timeout, then the connection with the proxy and TLS settings.
+func configureTransport(ctx context.Context, config authsignalProviderModel) (http.RoundTripper, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	maxRetries := int64(defaultMaxRetries)
+	if !config.MaxRetries.IsNull() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+
+	retryMaxWait := defaultRetryMaxWait
+	if !config.RetryMaxWait.IsNull() {
+		parsed, err := time.ParseDuration(config.RetryMaxWait.ValueString())
+		if err != nil || parsed <= 0 {
+			diags.AddAttributeError(
+				path.Root("retry_max_wait"),
+				"Invalid Retry Max Wait",
+				fmt.Sprintf("The retry_max_wait value %q is not a positive duration, such as `30s` or `2m`.", config.RetryMaxWait.ValueString()),
+			)
+		}
+		retryMaxWait = parsed
+	}
+
+	var proxy *url.URL
+	if httpProxy := stringConfigValue(config.HttpProxy, "AUTHSIGNAL_HTTP_PROXY"); httpProxy != "" {
+		parsed, err := url.Parse(httpProxy)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			diags.AddAttributeError(
+				path.Root("http_proxy"),
+				"Invalid HTTP Proxy",
+				fmt.Sprintf("The http_proxy value %q is not a URL with a scheme and host, such as `http://proxy.example.com:3128`.", httpProxy),
+			)
+		}
+		proxy = parsed
+	}
+
+	rootCAs, err := loadRootCAs(stringConfigValue(config.CaCertFile, "AUTHSIGNAL_CA_CERT_FILE"), stringConfigValue(config.CaCertPem, "AUTHSIGNAL_CA_CERT_PEM"))
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Unable to Load CA Certificates",
+			"The provider cannot load the CA certificates set in ca_cert_file or ca_cert_pem: "+err.Error(),
+		)
+	}
+
+	insecureSkipVerify, insecureDiags := boolConfigValue(config.InsecureSkipVerify, "AUTHSIGNAL_INSECURE_SKIP_VERIFY", path.Root("insecure_skip_verify"))
+	diags.Append(insecureDiags...)
+
+	if insecureSkipVerify {
+		diags.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Certificate Verification Is Disabled",
+			"insecure_skip_verify is set, so the provider does not check that it is talking to the real Authsignal Management API. "+
+				"Anyone able to intercept its traffic can read the API secret and change the tenant's configuration. "+
+				"Only use this for local testing, and trust a proxy's certificate with ca_cert_file or ca_cert_pem instead.",
+		)
+	}
+
+	var requestTimeout time.Duration
+	if timeout := stringConfigValue(config.RequestTimeout, "AUTHSIGNAL_REQUEST_TIMEOUT"); timeout != "" {
+		parsed, err := time.ParseDuration(timeout)
+		if err != nil || parsed <= 0 {
+			diags.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Request Timeout",
+				fmt.Sprintf("The request_timeout value %q is not a positive duration, such as `30s` or `2m`.", timeout),
+			)
+		}
+		requestTimeout = parsed
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var transport http.RoundTripper = newBaseTransport(proxy, rootCAs, insecureSkipVerify)
+
+	if requestTimeout > 0 {
+		transport = &timeoutTransport{next: transport, timeout: requestTimeout}
+	}
+
+	transport = &loggingTransport{next: transport}
+
+	if maxConcurrentRequests := config.MaxConcurrentRequests.ValueInt64(); maxConcurrentRequests > 0 {
+		transport = newConcurrencyTransport(transport, int(maxConcurrentRequests))
+	}
+
+	return newCacheTransport(newRetryTransport(transport, int(maxRetries), retryMaxWait), readCacheTTL), diags
+}
+
+// stringConfigValue returns the configured value of a string attribute, falling back to an
+// environment variable when it is not set.
+func stringConfigValue(value types.String, envVar string) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+
+	return os.Getenv(envVar)
+}
+
+// boolConfigValue returns the configured value of a boolean attribute, falling back to an environment
+// variable when it is not set.
+func boolConfigValue(value types.Bool, envVar string, attributePath path.Path) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !value.IsNull() {
+		return value.ValueBool(), diags
+	}
+
+	env := os.Getenv(envVar)
+	if env == "" {
+		return false, diags
+	}
+
+	parsed, err := strconv.ParseBool(env)
+	if err != nil {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid "+envVar,
+			fmt.Sprintf("The %s environment variable %q is not a boolean, such as `true` or `false`.", envVar, env),
+		)
+	}
+
+	return parsed, diags
+}
+
 func (p *authsignalProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewActionConfigurationDataSource,
@@ -170,6 +708,11 @@ func (p *authsignalProvider) DataSources(_ context.Context) []func() datasource.
 		NewCustomDataPointDataSource,
 		NewMessageOverridesDataSource,
 		NewMessageOverridesCatalogDataSource,
+		NewRuleEvaluationDataSource,
+		NewRulesDataSource,
+		NewActionConfigurationsDataSource,
+		NewValueListsDataSource,
+		NewCustomDataPointsDataSource,
 	}
 }
 
@@ -182,5 +725,18 @@ func (p *authsignalProvider) Resources(_ context.Context) []func() resource.Reso
 		NewCustomDataPointResource,
 		NewMessageOverridesResource,
 		NewPreBuiltUiSettingsResource,
+		NewActionRulesResource,
+		NewValueListItemResource,
+	}
+}
+
+func (p *authsignalProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewConditionAllFunction,
+		NewConditionAnyFunction,
+		NewConditionEqFunction,
+		NewConditionInListFunction,
+		NewConditionNotFunction,
+		NewNormalizeConditionsFunction,
 	}
 }
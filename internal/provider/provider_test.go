@@ -1,6 +1,10 @@
 package provider
 
 import (
+	"fmt"
+	"os"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -13,4 +17,59 @@ var (
 	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 		"authsignal": providerserver.NewProtocol6WithError(New("test")()),
 	}
+
+	// testAccFakeApi is the fake Management API the acceptance tests run against, or nil when they
+	// run against a real tenant.
+	testAccFakeApi *fakeApi
 )
+
+// TestMain points the acceptance tests at a fake Management API, so that they run offline and give
+// the same results every time. Set AUTHSIGNAL_TEST_LIVE to run them against the tenant that the
+// AUTHSIGNAL_* environment variables select instead, which checks what the fake only assumes.
+func TestMain(m *testing.M) {
+	if os.Getenv("TF_ACC") == "" {
+		os.Exit(m.Run())
+	}
+
+	if os.Getenv("AUTHSIGNAL_TEST_LIVE") != "" {
+		// A live run names its host, so that it never falls back to a region or profile by accident.
+		if os.Getenv("AUTHSIGNAL_HOST") == "" {
+			fmt.Fprintln(os.Stderr, "AUTHSIGNAL_TEST_LIVE runs the acceptance tests against the tenant at AUTHSIGNAL_HOST, which is not set.")
+			os.Exit(1)
+		}
+
+		os.Exit(m.Run())
+	}
+
+	testAccFakeApi = newFakeApi()
+
+	// Settings from the environment or a credentials file would otherwise fill in or conflict with
+	// those of the fake.
+	for _, name := range []string{"AUTHSIGNAL_REGION", "AUTHSIGNAL_API_SECRET_FILE", "AUTHSIGNAL_API_SECRET_COMMAND", "AUTHSIGNAL_PROFILE", "AUTHSIGNAL_READ_ONLY"} {
+		os.Unsetenv(name)
+	}
+	os.Setenv("AUTHSIGNAL_CREDENTIALS_FILE", os.DevNull)
+	os.Setenv("AUTHSIGNAL_HOST", testAccFakeApi.url())
+	os.Setenv("AUTHSIGNAL_TENANT_ID", fakeApiTenantId)
+	os.Setenv("AUTHSIGNAL_API_SECRET", fakeApiSecret)
+
+	code := m.Run()
+	testAccFakeApi.close()
+	os.Exit(code)
+}
+
+// testAccTenantId returns the ID of the tenant the acceptance tests run against.
+func testAccTenantId() string {
+	return os.Getenv("AUTHSIGNAL_TENANT_ID")
+}
+
+// testAccRequireFakeApi skips a test that injects errors, which only the fake Management API can do.
+func testAccRequireFakeApi(t *testing.T) *fakeApi {
+	t.Helper()
+
+	if testAccFakeApi == nil {
+		t.Skip("Injecting errors needs the fake Management API. Unset AUTHSIGNAL_TEST_LIVE to run this test.")
+	}
+
+	return testAccFakeApi
+}
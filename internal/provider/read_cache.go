@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// readCacheTTL is how long a cached response is used for.
+const readCacheTTL = 30 * time.Second
+
+// cacheTransport caches the responses to GET requests for up to ttl, so that data sources and
+// resources reading the same object during a Terraform run share one request. Identical GETs in
+// flight at the same time are sent once. Any other request invalidates the cached responses for the
+// object it writes to, the objects under it and the lists above it. A GET whose context is from
+// withFreshReads is always sent, as what it reads is written back.
+type cacheTransport struct {
+	next http.RoundTripper
+	ttl  time.Duration
+
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+
+	mu         sync.Mutex
+	responses  map[string]*cachedResponse
+	inFlight   map[string]*cacheFlight
+	generation uint64
+}
+
+// cachedResponse is a successful response to a GET, along with the path it was for and when it
+// stops being used.
+type cachedResponse struct {
+	path       string
+	expires    time.Time
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// cacheFlight is a GET in flight, which identical GETs wait on instead of sending their own.
+type cacheFlight struct {
+	done     chan struct{}
+	response *cachedResponse
+	err      error
+}
+
+// freshReadsKey marks a context whose GETs are not answered from the cache.
+type freshReadsKey struct{}
+
+// withFreshReads returns a context whose GETs are sent to the Management API rather than answered
+// from the cache, for a read whose result is written back, such as a value list's items.
+func withFreshReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshReadsKey{}, true)
+}
+
+func newCacheTransport(next http.RoundTripper, ttl time.Duration) *cacheTransport {
+	return &cacheTransport{
+		next:      next,
+		ttl:       ttl,
+		now:       time.Now,
+		responses: map[string]*cachedResponse{},
+		inFlight:  map[string]*cacheFlight{},
+	}
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		t.invalidate(req.URL.Path)
+		res, err := t.next.RoundTrip(req)
+		// Reads that started while the write was in flight may have cached the object as it was.
+		t.invalidate(req.URL.Path)
+		return res, err
+	}
+
+	// Tenants can share a host, so the key includes the credentials.
+	key := req.URL.String() + "\n" + req.Header.Get("Authorization")
+
+	if req.Context().Value(freshReadsKey{}) != nil {
+		return t.fresh(req, key)
+	}
+
+	t.mu.Lock()
+	if cached, ok := t.responses[key]; ok {
+		if t.now().Before(cached.expires) {
+			t.mu.Unlock()
+			return cached.response(req), nil
+		}
+		delete(t.responses, key)
+	}
+
+	if flight, ok := t.inFlight[key]; ok {
+		t.mu.Unlock()
+
+		select {
+		case <-flight.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		if flight.err != nil || flight.response == nil {
+			// The request that was in flight failed or was not cacheable, so this one is sent as well.
+			return t.next.RoundTrip(req)
+		}
+		return flight.response.response(req), nil
+	}
+
+	flight := &cacheFlight{done: make(chan struct{})}
+	t.inFlight[key] = flight
+	generation := t.generation
+	t.mu.Unlock()
+
+	res, cached, err := t.send(req)
+	flight.response = cached
+	flight.err = err
+
+	t.mu.Lock()
+	delete(t.inFlight, key)
+	// A write since the request was sent may have changed the object, so the response is not kept.
+	if flight.response != nil && t.generation == generation {
+		t.responses[key] = flight.response
+	}
+	t.mu.Unlock()
+	close(flight.done)
+
+	return res, err
+}
+
+// fresh sends a GET that must not be answered from the cache, and caches its response for the reads
+// after it.
+func (t *cacheTransport) fresh(req *http.Request, key string) (*http.Response, error) {
+	t.mu.Lock()
+	generation := t.generation
+	t.mu.Unlock()
+
+	res, cached, err := t.send(req)
+
+	t.mu.Lock()
+	if cached != nil && t.generation == generation {
+		t.responses[key] = cached
+	}
+	t.mu.Unlock()
+
+	return res, err
+}
+
+// send sends a GET, and returns its response to cache if it is successful.
+func (t *cacheTransport) send(req *http.Request) (*http.Response, *cachedResponse, error) {
+	res, err := t.next.RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusOK {
+		return res, nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	return res, &cachedResponse{
+		path:       req.URL.Path,
+		expires:    t.now().Add(t.ttl),
+		statusCode: res.StatusCode,
+		header:     res.Header.Clone(),
+		body:       body,
+	}, nil
+}
+
+// invalidate forgets the cached responses affected by a write to writePath.
+func (t *cacheTransport) invalidate(writePath string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.generation++
+	for key, cached := range t.responses {
+		if isPathWithin(cached.path, writePath) || isPathWithin(writePath, cached.path) {
+			delete(t.responses, key)
+		}
+	}
+}
+
+// isPathWithin reports whether path is parent or under it, such as `/actions/login/rules/123` under
+// `/actions/login`.
+func isPathWithin(path string, parent string) bool {
+	parent = strings.TrimSuffix(parent, "/")
+	path = strings.TrimSuffix(path, "/")
+
+	return path == parent || strings.HasPrefix(path, parent+"/")
+}
+
+func (c *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.statusCode, http.StatusText(c.statusCode)),
+		StatusCode:    c.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheTransport(t *testing.T) {
+	var requests sync.Map
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count, _ := requests.LoadOrStore(r.Method+" "+r.URL.Path, new(int64))
+		atomic.AddInt64(count.(*int64), 1)
+
+		if r.URL.Path == "/v1/management/slow" {
+			<-release
+		}
+		if r.URL.Path == "/v1/management/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	transport := newCacheTransport(http.DefaultTransport, time.Minute)
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+	client := &http.Client{Transport: transport}
+
+	sendWithContext := func(ctx context.Context, method string, endpoint string, apiSecret string) string {
+		req, _ := http.NewRequestWithContext(ctx, method, server.URL+"/v1/management"+endpoint, nil)
+		req.SetBasicAuth(apiSecret, "")
+
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer res.Body.Close()
+
+		body, _ := io.ReadAll(res.Body)
+		return string(body)
+	}
+
+	send := func(method string, endpoint string, apiSecret string) string {
+		return sendWithContext(context.Background(), method, endpoint, apiSecret)
+	}
+
+	sent := func(method string, endpoint string) int64 {
+		count, ok := requests.Load(method + " /v1/management" + endpoint)
+		if !ok {
+			return 0
+		}
+		return atomic.LoadInt64(count.(*int64))
+	}
+
+	t.Run("identical reads", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if body := send(http.MethodGet, "/theme", "secret"); body != "/v1/management/theme" {
+				t.Fatalf("bad body: %q", body)
+			}
+		}
+
+		if got := sent(http.MethodGet, "/theme"); got != 1 {
+			t.Fatalf("expected one request. got: %d", got)
+		}
+
+		send(http.MethodGet, "/theme", "other-secret")
+		if got := sent(http.MethodGet, "/theme"); got != 2 {
+			t.Fatalf("expected another tenant's read to be sent. got: %d", got)
+		}
+	})
+
+	t.Run("concurrent reads", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				send(http.MethodGet, "/slow", "secret")
+			}()
+		}
+
+		// The first read is held at the server while the others queue up behind it.
+		for sent(http.MethodGet, "/slow") == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if got := sent(http.MethodGet, "/slow"); got != 1 {
+			t.Fatalf("expected one request. got: %d", got)
+		}
+	})
+
+	t.Run("writes invalidate", func(t *testing.T) {
+		send(http.MethodGet, "/actions/login/rules", "secret")
+		send(http.MethodGet, "/actions/login/rules/123", "secret")
+		send(http.MethodGet, "/value-lists", "secret")
+
+		send(http.MethodPatch, "/actions/login/rules/123", "secret")
+
+		send(http.MethodGet, "/actions/login/rules", "secret")
+		send(http.MethodGet, "/actions/login/rules/123", "secret")
+		send(http.MethodGet, "/value-lists", "secret")
+
+		if got := sent(http.MethodGet, "/actions/login/rules"); got != 2 {
+			t.Fatalf("expected the list to be read again. got: %d", got)
+		}
+		if got := sent(http.MethodGet, "/actions/login/rules/123"); got != 2 {
+			t.Fatalf("expected the rule to be read again. got: %d", got)
+		}
+		if got := sent(http.MethodGet, "/value-lists"); got != 1 {
+			t.Fatalf("expected value lists to stay cached. got: %d", got)
+		}
+	})
+
+	t.Run("fresh reads", func(t *testing.T) {
+		send(http.MethodGet, "/value-lists/blocked", "secret")
+		sendWithContext(withFreshReads(context.Background()), http.MethodGet, "/value-lists/blocked", "secret")
+
+		if got := sent(http.MethodGet, "/value-lists/blocked"); got != 2 {
+			t.Fatalf("expected the fresh read to be sent. got: %d", got)
+		}
+
+		send(http.MethodGet, "/value-lists/blocked", "secret")
+		if got := sent(http.MethodGet, "/value-lists/blocked"); got != 2 {
+			t.Fatalf("expected the fresh read to be cached for the reads after it. got: %d", got)
+		}
+	})
+
+	t.Run("responses expire", func(t *testing.T) {
+		send(http.MethodGet, "/tenant", "secret")
+
+		now = now.Add(59 * time.Second)
+		send(http.MethodGet, "/tenant", "secret")
+		if got := sent(http.MethodGet, "/tenant"); got != 1 {
+			t.Fatalf("expected the read to be cached until it expires. got: %d", got)
+		}
+
+		now = now.Add(time.Second)
+		send(http.MethodGet, "/tenant", "secret")
+		if got := sent(http.MethodGet, "/tenant"); got != 2 {
+			t.Fatalf("expected the read to be sent again once expired. got: %d", got)
+		}
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		send(http.MethodGet, "/missing", "secret")
+		send(http.MethodGet, "/missing", "secret")
+
+		if got := sent(http.MethodGet, "/missing"); got != 2 {
+			t.Fatalf("expected both reads to be sent. got: %d", got)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+
+	client := newAuthsignalClient("https://api.authsignal.com/v1/management", "production", "secret")
+	client.readOnly = true
+
+	for _, newResource := range New("test")().Resources(ctx) {
+		r := newResource()
+
+		var metadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "authsignal"}, &metadata)
+
+		if configurable, ok := r.(resource.ResourceWithConfigure); ok {
+			var configure resource.ConfigureResponse
+			configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &configure)
+		}
+
+		// Each operation must fail before it reads its request, which is empty here.
+		var create resource.CreateResponse
+		r.Create(ctx, resource.CreateRequest{}, &create)
+		if !create.Diagnostics.HasError() {
+			t.Fatalf("expected %s to refuse to create", metadata.TypeName)
+		}
+
+		var update resource.UpdateResponse
+		r.Update(ctx, resource.UpdateRequest{}, &update)
+		if !update.Diagnostics.HasError() {
+			t.Fatalf("expected %s to refuse to update", metadata.TypeName)
+		}
+
+		// Deleting pre-built UI settings only removes them from state, so it is allowed.
+		if metadata.TypeName == "authsignal_pre_built_ui_settings" {
+			continue
+		}
+
+		var delete resource.DeleteResponse
+		r.Delete(ctx, resource.DeleteRequest{}, &delete)
+		if !delete.Diagnostics.HasError() {
+			t.Fatalf("expected %s to refuse to delete", metadata.TypeName)
+		}
+	}
+}
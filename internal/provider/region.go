@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// managementApiPath is the path the Management API is served under, on every region's host.
+const managementApiPath = "/v1/management"
+
+// regionHosts are the Management API hosts of Authsignal's regions, by the `region` that selects them.
+var regionHosts = map[string]string{
+	"us": "https://api.authsignal.com" + managementApiPath,
+	"au": "https://au.api.authsignal.com" + managementApiPath,
+	"eu": "https://eu.api.authsignal.com" + managementApiPath,
+	"ca": "https://ca.api.authsignal.com" + managementApiPath,
+}
+
+// hostSetting is a host or region, along with whether it was set in the configuration rather than
+// through an environment variable.
+type hostSetting struct {
+	value      string
+	fromConfig bool
+	path       path.Path
+}
+
+// resolveHost returns the Management API host given by a host or a region, or an empty string when
+// neither is set. The two are mutually exclusive, except that one set in the configuration takes
+// precedence over the other set in the environment.
+func resolveHost(host hostSetting, region hostSetting) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if host.value != "" && region.value != "" {
+		switch {
+		case host.fromConfig && !region.fromConfig:
+			region.value = ""
+		case region.fromConfig && !host.fromConfig:
+			host.value = ""
+		default:
+			diags.AddAttributeError(
+				region.path,
+				"Conflicting Authsignal API Host and Region",
+				fmt.Sprintf("Both a host (%q) and a region (%q) are set. The region determines the host, so set only one of them.", host.value, region.value),
+			)
+			return "", diags
+		}
+	}
+
+	if region.value != "" {
+		regionHost, ok := regionHosts[region.value]
+		if !ok {
+			diags.AddAttributeError(
+				region.path,
+				"Invalid Authsignal Region",
+				fmt.Sprintf("The region %q is not one of: %s.", region.value, strings.Join(sortedKeys(regionHosts), ", ")),
+			)
+			return "", diags
+		}
+
+		return regionHost, diags
+	}
+
+	if host.value == "" {
+		return "", diags
+	}
+
+	diags.Append(validateHost(host.value, host.path)...)
+	return strings.TrimSuffix(host.value, "/"), diags
+}
+
+// validateHost checks that host is the URL of a Management API, such as
+// `https://api.authsignal.com/v1/management`. A host that is a URL but not under the usual path is
+// only a warning, as it may be a proxy in front of the API.
+func validateHost(host string, hostPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	parsed, err := url.Parse(host)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		diags.AddAttributeError(
+			hostPath,
+			"Invalid Authsignal API Host",
+			fmt.Sprintf("The host %q is not a URL with a scheme and host, such as `%s`. Set region instead to use one of Authsignal's regions.", host, regionHosts["us"]),
+		)
+		return diags
+	}
+
+	if parsed.User != nil || parsed.RawQuery != "" || parsed.ForceQuery || parsed.Fragment != "" || strings.TrimSpace(host) != host {
+		diags.AddAttributeError(
+			hostPath,
+			"Invalid Authsignal API Host",
+			fmt.Sprintf("The host %q must be only the URL of the Management API, such as `%s`, without credentials, a query, a fragment or surrounding whitespace.", host, regionHosts["us"]),
+		)
+		return diags
+	}
+
+	if strings.TrimSuffix(parsed.Path, "/") != managementApiPath {
+		diags.AddAttributeWarning(
+			hostPath,
+			"Unexpected Authsignal API Host Path",
+			fmt.Sprintf("The host %q does not end in %s, the path the Management API is served under, so requests are likely to fail. This can be ignored if the host is a proxy that serves the API under another path.", host, managementApiPath),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestResolveHost(t *testing.T) {
+	testCases := []struct {
+		name     string
+		host     hostSetting
+		region   hostSetting
+		expected string
+		error    bool
+		warning  bool
+	}{
+		{
+			name:     "host",
+			host:     hostSetting{value: "https://api.authsignal.com/v1/management/", fromConfig: true},
+			expected: "https://api.authsignal.com/v1/management",
+		},
+		{
+			name:     "region",
+			region:   hostSetting{value: "au", fromConfig: true},
+			expected: "https://au.api.authsignal.com/v1/management",
+		},
+		{
+			name:   "unknown region",
+			region: hostSetting{value: "mars", fromConfig: true},
+			error:  true,
+		},
+		{
+			name:   "both in the configuration",
+			host:   hostSetting{value: "https://api.authsignal.com/v1/management", fromConfig: true},
+			region: hostSetting{value: "eu", fromConfig: true},
+			error:  true,
+		},
+		{
+			name:   "both in the environment",
+			host:   hostSetting{value: "https://api.authsignal.com/v1/management"},
+			region: hostSetting{value: "eu"},
+			error:  true,
+		},
+		{
+			name:     "region in the configuration overrides host in the environment",
+			host:     hostSetting{value: "https://api.authsignal.com/v1/management"},
+			region:   hostSetting{value: "eu", fromConfig: true},
+			expected: "https://eu.api.authsignal.com/v1/management",
+		},
+		{
+			name:     "host in the configuration overrides region in the environment",
+			host:     hostSetting{value: "https://ca.api.authsignal.com/v1/management", fromConfig: true},
+			region:   hostSetting{value: "eu"},
+			expected: "https://ca.api.authsignal.com/v1/management",
+		},
+		{
+			name:  "no scheme",
+			host:  hostSetting{value: "api.authsignal.com/v1/management", fromConfig: true},
+			error: true,
+		},
+		{
+			name:  "query",
+			host:  hostSetting{value: "https://api.authsignal.com/v1/management?x=1", fromConfig: true},
+			error: true,
+		},
+		{
+			name:  "trailing whitespace",
+			host:  hostSetting{value: "https://api.authsignal.com/v1/management ", fromConfig: true},
+			error: true,
+		},
+		{
+			name:     "missing path",
+			host:     hostSetting{value: "https://api.authsignal.com", fromConfig: true},
+			expected: "https://api.authsignal.com",
+			warning:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase.host.path = path.Root("host")
+		testCase.region.path = path.Root("region")
+
+		host, diags := resolveHost(testCase.host, testCase.region)
+
+		if diags.HasError() != testCase.error {
+			t.Fatalf("%s: expected error: %v. got: %v", testCase.name, testCase.error, diags)
+		}
+
+		if (diags.WarningsCount() > 0) != testCase.warning {
+			t.Fatalf("%s: expected warning: %v. got: %v", testCase.name, testCase.warning, diags)
+		}
+
+		if !testCase.error && host != testCase.expected {
+			t.Fatalf("%s: bad host. expected: %v. got : %v", testCase.name, testCase.expected, host)
+		}
+	}
+}
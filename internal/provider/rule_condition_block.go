@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// The `condition` block is a typed alternative to writing the rule's JsonLogic by hand. It is compiled
+// into the same JSON that `conditions` takes, so the API only ever sees one representation.
+
+var allowedMatchOperators = []string{"==", "!=", ">", ">=", "<", "<=", "in"}
+
+// valueListVarPrefix is how JsonLogic refers to a value list: `{"var": "valueLists.<alias>"}`.
+const valueListVarPrefix = "valueLists."
+
+type ruleConditionModel struct {
+	All *ruleConditionGroupModel `tfsdk:"all"`
+	Any *ruleConditionGroupModel `tfsdk:"any"`
+}
+
+type ruleConditionGroupModel struct {
+	Match []ruleConditionMatchModel     `tfsdk:"match"`
+	All   []ruleConditionLeafGroupModel `tfsdk:"all"`
+	Any   []ruleConditionLeafGroupModel `tfsdk:"any"`
+}
+
+// A group nested inside `all` or `any` can only hold matches, which keeps the schema finite.
+type ruleConditionLeafGroupModel struct {
+	Match []ruleConditionMatchModel `tfsdk:"match"`
+}
+
+type ruleConditionMatchModel struct {
+	Var          types.String  `tfsdk:"var"`
+	Op           types.String  `tfsdk:"op"`
+	StringValue  types.String  `tfsdk:"string_value"`
+	NumberValue  types.Float64 `tfsdk:"number_value"`
+	BoolValue    types.Bool    `tfsdk:"bool_value"`
+	StringValues types.List    `tfsdk:"string_values"`
+	ValueList    types.String  `tfsdk:"value_list"`
+}
+
+func ruleConditionMatchBlock() schema.ListNestedBlock {
+	exactlyOneValue := []path.Expression{
+		path.MatchRelative().AtParent().AtName("string_value"),
+		path.MatchRelative().AtParent().AtName("number_value"),
+		path.MatchRelative().AtParent().AtName("bool_value"),
+		path.MatchRelative().AtParent().AtName("string_values"),
+		path.MatchRelative().AtParent().AtName("value_list"),
+	}
+
+	return schema.ListNestedBlock{
+		Description: "Compares a tracked action's data point against a value. Exactly one of `string_value`, `number_value`, `bool_value`, `string_values` or `value_list` must be set.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"var": schema.StringAttribute{
+					Description: "The data point to compare, e.g. `ip.isAnonymous` or `custom.riskScore`.",
+					Required:    true,
+				},
+				"op": schema.StringAttribute{
+					Description: "The comparison operator. Allowed values: `==`, `!=`, `>`, `>=`, `<`, `<=`, `in`.",
+					Required:    true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(allowedMatchOperators...),
+					},
+				},
+				"string_value": schema.StringAttribute{
+					Description: "A string to compare the data point against.",
+					Optional:    true,
+					Validators: []validator.String{
+						stringvalidator.ExactlyOneOf(exactlyOneValue...),
+					},
+				},
+				"number_value": schema.Float64Attribute{
+					Description: "A number to compare the data point against.",
+					Optional:    true,
+				},
+				"bool_value": schema.BoolAttribute{
+					Description: "A boolean to compare the data point against.",
+					Optional:    true,
+				},
+				"string_values": schema.ListAttribute{
+					Description: "A list of strings the data point must be `in`.",
+					ElementType: types.StringType,
+					Optional:    true,
+				},
+				"value_list": schema.StringAttribute{
+					Description: "The alias of a value list the data point must be `in`.",
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+func ruleConditionGroupBlocks(nested bool) map[string]schema.Block {
+	blocks := map[string]schema.Block{
+		"match": ruleConditionMatchBlock(),
+	}
+
+	if nested {
+		return blocks
+	}
+
+	leafGroup := schema.ListNestedBlock{
+		NestedObject: schema.NestedBlockObject{
+			Blocks: ruleConditionGroupBlocks(true),
+		},
+	}
+
+	allGroup := leafGroup
+	allGroup.Description = "A nested group that matches when every one of its `match` blocks matches."
+	blocks["all"] = allGroup
+
+	anyGroup := leafGroup
+	anyGroup.Description = "A nested group that matches when any one of its `match` blocks matches."
+	blocks["any"] = anyGroup
+
+	return blocks
+}
+
+func ruleConditionBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "The rule's conditions as typed blocks, compiled by the provider into the JsonLogic held in `conditions`. Exactly one of `condition` or `conditions` must be set.",
+		Blocks: map[string]schema.Block{
+			"all": schema.SingleNestedBlock{
+				Description: "Matches when every nested `match`, `all` and `any` matches.",
+				Blocks:      ruleConditionGroupBlocks(false),
+			},
+			"any": schema.SingleNestedBlock{
+				Description: "Matches when any nested `match`, `all` or `any` matches.",
+				Blocks:      ruleConditionGroupBlocks(false),
+			},
+		},
+	}
+}
+
+// validateConditionBlock checks that a `condition` block holds exactly one of `all` or `any`. It is
+// done here rather than with a validator on the block, since the block is validated even when it is
+// absent, and a validator there counts the block itself as one of the values set.
+func validateConditionBlock(condition types.Object) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if condition.IsNull() || condition.IsUnknown() {
+		return diags
+	}
+
+	count := 0
+	for _, name := range []string{"all", "any"} {
+		group := condition.Attributes()[name]
+		if group == nil {
+			continue
+		}
+		if group.IsUnknown() {
+			return diags
+		}
+		if !group.IsNull() {
+			count++
+		}
+	}
+
+	if count != 1 {
+		diags.AddAttributeError(
+			path.Root("condition"),
+			"Invalid Attribute Combination",
+			"Exactly one of the `all` or `any` blocks must be set in the `condition` block.",
+		)
+	}
+
+	return diags
+}
+
+// buildConditionsFromBlock compiles a `condition` block into a JsonLogic string. It returns an empty
+// string when the block is null, or when parts of it are not known until apply.
+func buildConditionsFromBlock(ctx context.Context, conditionObject types.Object) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if conditionObject.IsNull() || conditionObject.IsUnknown() {
+		return "", diags
+	}
+
+	terraformValue, err := conditionObject.ToTerraformValue(ctx)
+	if err != nil || !terraformValue.IsFullyKnown() {
+		return "", diags
+	}
+
+	var condition ruleConditionModel
+	diags.Append(conditionObject.As(ctx, &condition, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	conditionPath := path.Root("condition")
+
+	var logic map[string]any
+	if condition.All != nil {
+		logic = compileConditionGroup(ctx, "and", conditionPath.AtName("all"), condition.All.Match, condition.All.All, condition.All.Any, &diags)
+	} else if condition.Any != nil {
+		logic = compileConditionGroup(ctx, "or", conditionPath.AtName("any"), condition.Any.Match, condition.Any.All, condition.Any.Any, &diags)
+	}
+
+	if diags.HasError() || logic == nil {
+		return "", diags
+	}
+
+	// Encoded the same way Read encodes the conditions the API returns, so an unchanged block
+	// compiles to exactly what is already in state.
+	conditionsJson, err := json.Marshal(logic)
+	if err != nil {
+		diags.AddAttributeError(conditionPath, "Unable to marshal conditions", err.Error())
+		return "", diags
+	}
+
+	return string(conditionsJson), diags
+}
+
+func compileConditionGroup(ctx context.Context, operator string, groupPath path.Path, matches []ruleConditionMatchModel, allGroups []ruleConditionLeafGroupModel, anyGroups []ruleConditionLeafGroupModel, diags *diag.Diagnostics) map[string]any {
+	operands := make([]any, 0, len(matches)+len(allGroups)+len(anyGroups))
+
+	for i, match := range matches {
+		operands = append(operands, compileConditionMatch(ctx, groupPath.AtName("match").AtListIndex(i), match, diags))
+	}
+
+	for i, group := range allGroups {
+		operands = append(operands, compileConditionGroup(ctx, "and", groupPath.AtName("all").AtListIndex(i), group.Match, nil, nil, diags))
+	}
+
+	for i, group := range anyGroups {
+		operands = append(operands, compileConditionGroup(ctx, "or", groupPath.AtName("any").AtListIndex(i), group.Match, nil, nil, diags))
+	}
+
+	if len(operands) == 0 {
+		diags.AddAttributeError(
+			groupPath,
+			"Empty condition group",
+			"A condition group must contain at least one `match`, `all` or `any` block.",
+		)
+		return nil
+	}
+
+	return map[string]any{operator: operands}
+}
+
+func compileConditionMatch(ctx context.Context, matchPath path.Path, match ruleConditionMatchModel, diags *diag.Diagnostics) map[string]any {
+	op := match.Op.ValueString()
+	dataPoint := map[string]any{"var": match.Var.ValueString()}
+
+	var value any
+	switch {
+	case !match.StringValue.IsNull():
+		value = match.StringValue.ValueString()
+	case !match.NumberValue.IsNull():
+		value = match.NumberValue.ValueFloat64()
+	case !match.BoolValue.IsNull():
+		value = match.BoolValue.ValueBool()
+	case !match.StringValues.IsNull():
+		values := make([]string, 0, len(match.StringValues.Elements()))
+		diags.Append(match.StringValues.ElementsAs(ctx, &values, false)...)
+		value = values
+	case !match.ValueList.IsNull():
+		value = map[string]any{"var": valueListVarPrefix + match.ValueList.ValueString()}
+	default:
+		diags.AddAttributeError(
+			matchPath,
+			"Missing match value",
+			"Exactly one of `string_value`, `number_value`, `bool_value`, `string_values` or `value_list` must be set.",
+		)
+		return nil
+	}
+
+	if (!match.StringValues.IsNull() || !match.ValueList.IsNull()) && op != "in" {
+		diags.AddAttributeError(
+			matchPath.AtName("op"),
+			"Invalid match operator",
+			fmt.Sprintf("`string_values` and `value_list` can only be compared with the `in` operator. Got: %q", op),
+		)
+		return nil
+	}
+
+	return map[string]any{op: []any{dataPoint, value}}
+}
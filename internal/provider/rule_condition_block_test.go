@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestConditionBlockCompilesToJsonLogic(t *testing.T) {
+	ctx := context.Background()
+
+	match := func(dataPoint string, op string) ruleConditionMatchModel {
+		return ruleConditionMatchModel{
+			Var:          types.StringValue(dataPoint),
+			Op:           types.StringValue(op),
+			StringValue:  types.StringNull(),
+			NumberValue:  types.Float64Null(),
+			BoolValue:    types.BoolNull(),
+			StringValues: types.ListNull(types.StringType),
+			ValueList:    types.StringNull(),
+		}
+	}
+
+	isAnonymous := match("ip.isAnonymous", "==")
+	isAnonymous.BoolValue = types.BoolValue(false)
+
+	riskScore := match("custom.riskScore", ">=")
+	riskScore.NumberValue = types.Float64Value(80)
+
+	country := match("ip.countryCode", "in")
+	country.StringValues = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("NZ"), types.StringValue("AU")})
+
+	blockedEmails := match("user.email", "in")
+	blockedEmails.ValueList = types.StringValue("blocked-emails")
+
+	testCases := []struct {
+		name         string
+		operator     string
+		matches      []ruleConditionMatchModel
+		anyGroups    []ruleConditionLeafGroupModel
+		expectedJson string
+	}{
+		{
+			name:         "single match",
+			operator:     "and",
+			matches:      []ruleConditionMatchModel{isAnonymous},
+			expectedJson: `{"and":[{"==":[{"var":"ip.isAnonymous"},false]}]}`,
+		},
+		{
+			name:         "number and list",
+			operator:     "or",
+			matches:      []ruleConditionMatchModel{riskScore, country},
+			expectedJson: `{"or":[{"\u003e=":[{"var":"custom.riskScore"},80]},{"in":[{"var":"ip.countryCode"},["NZ","AU"]]}]}`,
+		},
+		{
+			name:         "nested group with value list",
+			operator:     "and",
+			matches:      []ruleConditionMatchModel{isAnonymous},
+			anyGroups:    []ruleConditionLeafGroupModel{{Match: []ruleConditionMatchModel{blockedEmails, riskScore}}},
+			expectedJson: `{"and":[{"==":[{"var":"ip.isAnonymous"},false]},{"or":[{"in":[{"var":"user.email"},{"var":"valueLists.blocked-emails"}]},{"\u003e=":[{"var":"custom.riskScore"},80]}]}]}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			logic := compileConditionGroup(ctx, testCase.operator, path.Root("condition"), testCase.matches, nil, testCase.anyGroups, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			jsonBody, err := json.Marshal(logic)
+			if err != nil {
+				t.Fatalf("failed to marshal json: %v", err)
+			}
+
+			if string(jsonBody) != testCase.expectedJson {
+				t.Fatalf("bad json. expected: %v. got : %v", testCase.expectedJson, string(jsonBody))
+			}
+		})
+	}
+}
+
+func TestConditionBlockRejectsInvalidGroups(t *testing.T) {
+	ctx := context.Background()
+
+	listWithEquals := ruleConditionMatchModel{
+		Var:          types.StringValue("user.email"),
+		Op:           types.StringValue("=="),
+		StringValue:  types.StringNull(),
+		NumberValue:  types.Float64Null(),
+		BoolValue:    types.BoolNull(),
+		StringValues: types.ListNull(types.StringType),
+		ValueList:    types.StringValue("blocked-emails"),
+	}
+
+	testCases := []struct {
+		name    string
+		matches []ruleConditionMatchModel
+	}{
+		{name: "empty group"},
+		{name: "value list without in", matches: []ruleConditionMatchModel{listWithEquals}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			compileConditionGroup(ctx, "and", path.Root("condition"), testCase.matches, nil, nil, &diags)
+			if !diags.HasError() {
+				t.Fatalf("expected an error diagnostic")
+			}
+		})
+	}
+}
+
+func TestRuleResourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&ruleResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	ruleType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	conditionType := ruleType.AttributeTypes["condition"].(tftypes.Object)
+	groupType := conditionType.AttributeTypes["all"].(tftypes.Object)
+	matchType := groupType.AttributeTypes["match"].(tftypes.List).ElementType.(tftypes.Object)
+
+	group := testTerraformObject(groupType, map[string]tftypes.Value{
+		"match": tftypes.NewValue(tftypes.List{ElementType: matchType}, []tftypes.Value{
+			testTerraformObject(matchType, map[string]tftypes.Value{
+				"var":        tftypes.NewValue(tftypes.String, "ip.isAnonymous"),
+				"op":         tftypes.NewValue(tftypes.String, "=="),
+				"bool_value": tftypes.NewValue(tftypes.Bool, false),
+			}),
+		}),
+	})
+
+	rule := map[string]tftypes.Value{
+		"name":        tftypes.NewValue(tftypes.String, "Terraform Acc Test Rule"),
+		"action_code": tftypes.NewValue(tftypes.String, "signIn"),
+		"is_active":   tftypes.NewValue(tftypes.Bool, true),
+		"priority":    tftypes.NewValue(tftypes.Number, 1),
+		"type":        tftypes.NewValue(tftypes.String, "BLOCK"),
+	}
+
+	withAttribute := func(name string, value tftypes.Value) map[string]tftypes.Value {
+		values := map[string]tftypes.Value{name: value}
+		for key, value := range rule {
+			values[key] = value
+		}
+		return values
+	}
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for name, testCase := range map[string]struct {
+		values        map[string]tftypes.Value
+		expectedError bool
+	}{
+		"conditions": {
+			values: withAttribute("conditions", tftypes.NewValue(tftypes.String, `{"and":[{"==":[{"var":"ip.isAnonymous"},false]}]}`)),
+		},
+		"condition block": {
+			values: withAttribute("condition", testTerraformObject(conditionType, map[string]tftypes.Value{"all": group})),
+		},
+		"condition block without a group": {
+			values:        withAttribute("condition", testTerraformObject(conditionType, nil)),
+			expectedError: true,
+		},
+		"condition block with both groups": {
+			values:        withAttribute("condition", testTerraformObject(conditionType, map[string]tftypes.Value{"all": group, "any": group})),
+			expectedError: true,
+		},
+		"neither": {
+			values:        rule,
+			expectedError: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config, err := tfprotov6.NewDynamicValue(ruleType, testTerraformObject(ruleType, testCase.values))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+				TypeName: "authsignal_rule",
+				Config:   &config,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			hasError := false
+			for _, diagnostic := range resp.Diagnostics {
+				if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+					hasError = true
+				}
+			}
+
+			if hasError != testCase.expectedError {
+				t.Fatalf("expected error: %t. got diagnostics: %+v", testCase.expectedError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// testTerraformObject returns an object of objectType with values set, and every other attribute null.
+func testTerraformObject(objectType tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		} else {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+
+	return tftypes.NewValue(objectType, attributes)
+}
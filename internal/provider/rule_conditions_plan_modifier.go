@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// When a rule is written with the `condition` block, `conditions` is computed from it at plan time so
+// the plan shows the exact JsonLogic that will be sent, and a rule edited outside Terraform shows up
+// as a diff against the block rather than being silently kept.
+type conditionsFromConditionBlock struct{}
+
+func (m conditionsFromConditionBlock) Description(_ context.Context) string {
+	return "Compiles the condition block into conditions when conditions is not set directly."
+}
+
+func (m conditionsFromConditionBlock) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m conditionsFromConditionBlock) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var condition types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("condition"), &condition)...)
+	if resp.Diagnostics.HasError() || condition.IsNull() {
+		return
+	}
+
+	conditions, diags := buildConditionsFromBlock(ctx, condition)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || conditions == "" {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+
+	// Keep the stored JSON when it is the same document, however the API happened to format it.
+	if !req.StateValue.IsNull() && jsonSemanticallyEqual(req.StateValue.ValueString(), conditions) {
+		resp.PlanValue = req.StateValue
+		return
+	}
+
+	resp.PlanValue = types.StringValue(conditions)
+}
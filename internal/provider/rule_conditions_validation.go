@@ -0,0 +1,363 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// The JsonLogic operators a rule's conditions may use. Anything else is almost certainly a typo, and
+// the API would otherwise only reject it at apply time.
+var allowedConditionOperators = []string{
+	"var", "missing", "missing_some",
+	"if", "?:",
+	"==", "===", "!=", "!==", "!", "!!",
+	"or", "and",
+	">", ">=", "<", "<=",
+	"max", "min", "+", "-", "*", "/", "%",
+	"map", "filter", "reduce", "all", "none", "some", "merge",
+	"in", "cat", "substr",
+}
+
+// Comparisons whose operands are checked against the data type of a custom data point.
+var comparisonConditionOperators = []string{"==", "===", "!=", "!==", ">", ">=", "<", "<=", "in"}
+
+const customDataPointVarPrefix = "custom."
+
+// conditionReferenceLookup resolves the tenant objects a rule's conditions refer to. It is satisfied
+// by the Management API client in normal use and by a stand-in in tests.
+type conditionReferenceLookup interface {
+	customDataPointDataType(id string) (dataType string, found bool, err error)
+	valueListExists(alias string) (found bool, err error)
+}
+
+type clientConditionReferenceLookup struct {
+	ctx    context.Context
+	client *authsignalClient
+}
+
+func (l clientConditionReferenceLookup) customDataPointDataType(id string) (string, bool, error) {
+	customDataPoint, statusCode, err := l.client.GetCustomDataPoint(l.ctx, id)
+	if statusCode == 404 {
+		return "", false, nil
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+
+	return customDataPoint.DataType, true, nil
+}
+
+func (l clientConditionReferenceLookup) valueListExists(alias string) (bool, error) {
+	_, statusCode, err := l.client.GetValueList(l.ctx, alias)
+	if statusCode == 404 {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// customDataPointComparison is a comparison between a `custom.*` data point and a literal.
+type customDataPointComparison struct {
+	location    string
+	dataPointId string
+	literal     any
+}
+
+type conditionReferences struct {
+	customDataPoints []customDataPointComparison
+	customDataPoint  map[string]string
+	valueLists       map[string]string
+}
+
+// validateConditionOperators walks a JsonLogic document and reports any operator that is not in the
+// allowlist, along with where in the document it was found.
+func validateConditionOperators(conditionsPath path.Path, conditions string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var logic any
+	if err := json.Unmarshal([]byte(conditions), &logic); err != nil {
+		diags.AddAttributeError(conditionsPath, "Invalid rule conditions", "The conditions are not valid JSON: "+err.Error())
+		return diags
+	}
+
+	walkConditions(logic, "$", func(location string, operator string, _ any) {
+		if !contains(allowedConditionOperators, operator) {
+			diags.AddAttributeError(
+				conditionsPath,
+				"Unsupported condition operator",
+				fmt.Sprintf("The operator %q at %s is not a supported JsonLogic operator. Allowed operators: %s.", operator, location, strings.Join(allowedConditionOperators, ", ")),
+			)
+		}
+	})
+
+	return diags
+}
+
+// validateConditionReferences checks that every custom data point and value list the conditions refer
+// to exists on the tenant, and that custom data points are compared with literals of their data type.
+// A reference the tenant doesn't have is only a warning, since the same apply may create it, and so is
+// a lookup that fails for another reason, so an unreachable API does not block planning.
+func validateConditionReferences(conditionsPath path.Path, conditions string, lookup conditionReferenceLookup) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var logic any
+	if err := json.Unmarshal([]byte(conditions), &logic); err != nil {
+		return diags
+	}
+
+	references := collectConditionReferences(logic)
+
+	dataTypes := map[string]string{}
+	for _, id := range sortedKeys(references.customDataPoint) {
+		dataType, found, err := lookup.customDataPointDataType(id)
+		if err != nil {
+			diags.AddAttributeWarning(
+				conditionsPath,
+				"Unable to check custom data point",
+				fmt.Sprintf("Could not read custom data point %q referenced at %s: %s", id, references.customDataPoint[id], err.Error()),
+			)
+			continue
+		}
+
+		if !found {
+			diags.AddAttributeWarning(
+				conditionsPath,
+				"Unknown custom data point",
+				fmt.Sprintf("The conditions refer to %q at %s, but the tenant has no custom data point with ID %q yet. "+
+					"This is expected if an `authsignal_custom_data_point` in this configuration creates it; otherwise check the ID for typos.",
+					customDataPointVarPrefix+id, references.customDataPoint[id], id),
+			)
+			continue
+		}
+
+		dataTypes[id] = dataType
+	}
+
+	for _, comparison := range references.customDataPoints {
+		dataType, ok := dataTypes[comparison.dataPointId]
+		if !ok || literalMatchesDataType(dataType, comparison.literal) {
+			continue
+		}
+
+		diags.AddAttributeError(
+			conditionsPath,
+			"Mismatched custom data point type",
+			fmt.Sprintf("The custom data point %q is of type %q, but is compared with %s at %s.", comparison.dataPointId, dataType, describeLiteral(comparison.literal), comparison.location),
+		)
+	}
+
+	for _, alias := range sortedKeys(references.valueLists) {
+		found, err := lookup.valueListExists(alias)
+		if err != nil {
+			diags.AddAttributeWarning(
+				conditionsPath,
+				"Unable to check value list",
+				fmt.Sprintf("Could not read value list %q referenced at %s: %s", alias, references.valueLists[alias], err.Error()),
+			)
+			continue
+		}
+
+		if !found {
+			diags.AddAttributeWarning(
+				conditionsPath,
+				"Unknown value list",
+				fmt.Sprintf("The conditions refer to value list %q at %s, but the tenant has no value list with that alias yet. "+
+					"This is expected if an `authsignal_value_list` in this configuration creates it; otherwise check the alias for typos.",
+					alias, references.valueLists[alias]),
+			)
+		}
+	}
+
+	return diags
+}
+
+func collectConditionReferences(logic any) conditionReferences {
+	references := conditionReferences{
+		customDataPoint: map[string]string{},
+		valueLists:      map[string]string{},
+	}
+
+	walkConditions(logic, "$", func(location string, operator string, args any) {
+		if operator == "var" {
+			name := varName(args)
+			if id, ok := strings.CutPrefix(name, customDataPointVarPrefix); ok && id != "" {
+				if _, seen := references.customDataPoint[id]; !seen {
+					references.customDataPoint[id] = location
+				}
+			}
+
+			if alias, ok := strings.CutPrefix(name, valueListVarPrefix); ok && alias != "" {
+				if _, seen := references.valueLists[alias]; !seen {
+					references.valueLists[alias] = location
+				}
+			}
+
+			return
+		}
+
+		if !contains(comparisonConditionOperators, operator) {
+			return
+		}
+
+		operands, ok := args.([]any)
+		if !ok || len(operands) != 2 {
+			return
+		}
+
+		for i, operand := range operands {
+			id, ok := customDataPointOperand(operand)
+			if !ok {
+				continue
+			}
+
+			literal := operands[1-i]
+			if isConditionOperation(literal) {
+				continue
+			}
+
+			references.customDataPoints = append(references.customDataPoints, customDataPointComparison{
+				location:    location,
+				dataPointId: id,
+				literal:     literal,
+			})
+		}
+	})
+
+	return references
+}
+
+// walkConditions calls visit for every operation in a JsonLogic document. An operation is an object
+// with a single key, the operator, whose value holds the operator's arguments.
+func walkConditions(node any, location string, visit func(location string, operator string, args any)) {
+	switch value := node.(type) {
+	case map[string]any:
+		if len(value) != 1 {
+			for _, key := range sortedKeys(value) {
+				walkConditions(value[key], location+"."+key, visit)
+			}
+			return
+		}
+
+		for operator, args := range value {
+			operationLocation := location + "." + operator
+			visit(operationLocation, operator, args)
+
+			if operator == "var" {
+				return
+			}
+
+			walkConditions(args, operationLocation, visit)
+		}
+	case []any:
+		for i, item := range value {
+			walkConditions(item, fmt.Sprintf("%s[%d]", location, i), visit)
+		}
+	}
+}
+
+func isConditionOperation(node any) bool {
+	operation, ok := node.(map[string]any)
+	return ok && len(operation) == 1
+}
+
+func customDataPointOperand(node any) (string, bool) {
+	operation, ok := node.(map[string]any)
+	if !ok || len(operation) != 1 {
+		return "", false
+	}
+
+	args, ok := operation["var"]
+	if !ok {
+		return "", false
+	}
+
+	id, ok := strings.CutPrefix(varName(args), customDataPointVarPrefix)
+	return id, ok && id != ""
+}
+
+// varName returns the data point a `var` operation reads, which is either the argument itself or the
+// first element of `[name, default]`.
+func varName(args any) string {
+	switch value := args.(type) {
+	case string:
+		return value
+	case []any:
+		if len(value) > 0 {
+			if name, ok := value[0].(string); ok {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
+
+func literalMatchesDataType(dataType string, literal any) bool {
+	if items, ok := literal.([]any); ok {
+		for _, item := range items {
+			if !literalMatchesDataType(dataType, item) {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch dataType {
+	case "text", "multiselect":
+		_, ok := literal.(string)
+		return ok
+	case "number":
+		_, ok := literal.(float64)
+		return ok
+	case "boolean":
+		_, ok := literal.(bool)
+		return ok
+	}
+
+	return true
+}
+
+func describeLiteral(literal any) string {
+	switch literal.(type) {
+	case string:
+		return fmt.Sprintf("the string %q", literal)
+	case float64:
+		return fmt.Sprintf("the number %v", literal)
+	case bool:
+		return fmt.Sprintf("the boolean %v", literal)
+	case nil:
+		return "null"
+	}
+
+	encoded, _ := json.Marshal(literal)
+	return string(encoded)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// fakeConditionReferenceLookup stands in for the Management API so condition references can be
+// validated offline.
+type fakeConditionReferenceLookup struct {
+	customDataPoints map[string]string
+	valueLists       map[string]bool
+	err              error
+	calls            int
+}
+
+func (l *fakeConditionReferenceLookup) customDataPointDataType(id string) (string, bool, error) {
+	l.calls++
+	if l.err != nil {
+		return "", false, l.err
+	}
+
+	dataType, found := l.customDataPoints[id]
+	return dataType, found, nil
+}
+
+func (l *fakeConditionReferenceLookup) valueListExists(alias string) (bool, error) {
+	l.calls++
+	if l.err != nil {
+		return false, l.err
+	}
+
+	return l.valueLists[alias], nil
+}
+
+func diagnosticSummaries(diags diag.Diagnostics, severity diag.Severity) []string {
+	var summaries []string
+	for _, d := range diags {
+		if d.Severity() == severity {
+			summaries = append(summaries, d.Summary())
+		}
+	}
+	return summaries
+}
+
+func TestConditionOperatorValidation(t *testing.T) {
+	testCases := []struct {
+		name           string
+		conditions     string
+		expectedErrors int
+	}{
+		{
+			name:       "standard operators",
+			conditions: `{"and":[{"==":[{"var":"ip.isAnonymous"},true]},{"in":[{"var":"user.email"},{"var":"valueLists.blocked"}]}]}`,
+		},
+		{
+			name:           "misspelled operator",
+			conditions:     `{"and":[{"equals":[{"var":"ip.isAnonymous"},true]}]}`,
+			expectedErrors: 1,
+		},
+		{
+			name:           "unknown operators at several depths",
+			conditions:     `{"xor":[{"or":[{"=~":[{"var":"user.email"},"x"]}]}]}`,
+			expectedErrors: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			diags := validateConditionOperators(path.Root("conditions"), testCase.conditions)
+			if diags.ErrorsCount() != testCase.expectedErrors {
+				t.Fatalf("bad error count. expected: %v. got : %v (%v)", testCase.expectedErrors, diags.ErrorsCount(), diags)
+			}
+		})
+	}
+}
+
+func TestConditionReferenceValidation(t *testing.T) {
+	tenant := func() *fakeConditionReferenceLookup {
+		return &fakeConditionReferenceLookup{
+			customDataPoints: map[string]string{
+				"riskScore":   "number",
+				"isTrusted":   "boolean",
+				"accountType": "text",
+				"tags":        "multiselect",
+			},
+			valueLists: map[string]bool{"blocked-emails": true},
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		conditions       string
+		lookup           *fakeConditionReferenceLookup
+		expectedErrors   []string
+		expectedWarnings []string
+	}{
+		{
+			name:       "matching references",
+			conditions: `{"and":[{">=":[{"var":"custom.riskScore"},80]},{"==":[{"var":"custom.isTrusted"},false]},{"in":[{"var":"custom.accountType"},["free","trial"]]},{"in":[{"var":"user.email"},{"var":"valueLists.blocked-emails"}]}]}`,
+			lookup:     tenant(),
+		},
+		{
+			name:             "unknown custom data point",
+			conditions:       `{"==":[{"var":"custom.riskScor"},80]}`,
+			lookup:           tenant(),
+			expectedWarnings: []string{"Unknown custom data point"},
+		},
+		{
+			name:             "unknown value list",
+			conditions:       `{"in":[{"var":"user.email"},{"var":"valueLists.blocked-email"}]}`,
+			lookup:           tenant(),
+			expectedWarnings: []string{"Unknown value list"},
+		},
+		{
+			name:           "number compared with string",
+			conditions:     `{">=":[{"var":"custom.riskScore"},"80"]}`,
+			lookup:         tenant(),
+			expectedErrors: []string{"Mismatched custom data point type"},
+		},
+		{
+			name:           "boolean compared with string on the left",
+			conditions:     `{"==":["true",{"var":"custom.isTrusted"}]}`,
+			lookup:         tenant(),
+			expectedErrors: []string{"Mismatched custom data point type"},
+		},
+		{
+			name:       "multiselect contains string",
+			conditions: `{"in":["vip",{"var":"custom.tags"}]}`,
+			lookup:     tenant(),
+		},
+		{
+			name:       "compared with another data point",
+			conditions: `{"==":[{"var":"custom.riskScore"},{"var":"custom.accountType"}]}`,
+			lookup:     tenant(),
+		},
+		{
+			name:             "api unavailable",
+			conditions:       `{"and":[{"==":[{"var":"custom.riskScore"},80]},{"in":[{"var":"user.email"},{"var":"valueLists.blocked-emails"}]}]}`,
+			lookup:           &fakeConditionReferenceLookup{err: errors.New("connection refused")},
+			expectedWarnings: []string{"Unable to check custom data point", "Unable to check value list"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			diags := validateConditionReferences(path.Root("conditions"), testCase.conditions, testCase.lookup)
+
+			errorSummaries := diagnosticSummaries(diags, diag.SeverityError)
+			if len(errorSummaries) != len(testCase.expectedErrors) {
+				t.Fatalf("bad errors. expected: %v. got : %v", testCase.expectedErrors, errorSummaries)
+			}
+			for i := range errorSummaries {
+				if errorSummaries[i] != testCase.expectedErrors[i] {
+					t.Fatalf("bad errors. expected: %v. got : %v", testCase.expectedErrors, errorSummaries)
+				}
+			}
+
+			warningSummaries := diagnosticSummaries(diags, diag.SeverityWarning)
+			if len(warningSummaries) != len(testCase.expectedWarnings) {
+				t.Fatalf("bad warnings. expected: %v. got : %v", testCase.expectedWarnings, warningSummaries)
+			}
+			for i := range warningSummaries {
+				if warningSummaries[i] != testCase.expectedWarnings[i] {
+					t.Fatalf("bad warnings. expected: %v. got : %v", testCase.expectedWarnings, warningSummaries)
+				}
+			}
+		})
+	}
+}
+
+func TestConditionReferencesAreLookedUpOnce(t *testing.T) {
+	lookup := &fakeConditionReferenceLookup{
+		customDataPoints: map[string]string{"riskScore": "number"},
+		valueLists:       map[string]bool{"blocked-emails": true},
+	}
+
+	conditions := `{"or":[{">":[{"var":"custom.riskScore"},50]},{"<":[{"var":"custom.riskScore"},10]},{"in":[{"var":"user.email"},{"var":"valueLists.blocked-emails"}]},{"in":[{"var":"user.username"},{"var":"valueLists.blocked-emails"}]}]}`
+
+	diags := validateConditionReferences(path.Root("conditions"), conditions, lookup)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if lookup.calls != 2 {
+		t.Fatalf("bad lookup count. expected: %v. got : %v", 2, lookup.calls)
+	}
+}
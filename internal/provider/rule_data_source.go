@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/authsignal/authsignal-management-go/v6"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -22,22 +21,23 @@ func NewRuleDataSource() datasource.DataSource {
 }
 
 type ruleDataSource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 type ruleDataSourceModel struct {
-	Name                              types.String `tfsdk:"name"`
-	Description                       types.String `tfsdk:"description"`
-	IsActive                          types.Bool   `tfsdk:"is_active"`
-	Priority                          types.Int64  `tfsdk:"priority"`
-	ActionCode                        types.String `tfsdk:"action_code"`
-	RuleId                            types.String `tfsdk:"rule_id"`
-	TenantId                          types.String `tfsdk:"tenant_id"`
-	Type                              types.String `tfsdk:"type"`
-	VerificationMethods               types.List   `tfsdk:"verification_methods"`
-	PromptToEnrollVerificationMethods types.List   `tfsdk:"prompt_to_enroll_verification_methods"`
-	DefaultVerificationMethod         types.String `tfsdk:"default_verification_method"`
-	Conditions                        types.String `tfsdk:"conditions"`
+	Name                              types.String        `tfsdk:"name"`
+	Description                       types.String        `tfsdk:"description"`
+	IsActive                          types.Bool          `tfsdk:"is_active"`
+	Priority                          types.Int64         `tfsdk:"priority"`
+	ActionCode                        types.String        `tfsdk:"action_code"`
+	RuleId                            types.String        `tfsdk:"rule_id"`
+	TenantId                          types.String        `tfsdk:"tenant_id"`
+	Type                              types.String        `tfsdk:"type"`
+	VerificationMethods               types.List          `tfsdk:"verification_methods"`
+	PromptToEnrollVerificationMethods types.List          `tfsdk:"prompt_to_enroll_verification_methods"`
+	DefaultVerificationMethod         types.String        `tfsdk:"default_verification_method"`
+	Conditions                        normalizedJsonValue `tfsdk:"conditions"`
+	Tenant                            types.String        `tfsdk:"tenant"`
 }
 
 func (d *ruleDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -47,6 +47,7 @@ func (d *ruleDataSource) Metadata(_ context.Context, req datasource.MetadataRequ
 func (d *ruleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantDataSourceAttribute(),
 			"action_code": schema.StringAttribute{
 				Description: "The name of the action that users perform which you will track. (e.g 'login')",
 				Required:    true,
@@ -87,6 +88,7 @@ func (d *ruleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 			},
 			"conditions": schema.StringAttribute{
 				Description: "The logical conditions to match tracked actions against. If the conditions are met then the rule's type will be returned in the track action response.",
+				CustomType:  normalizedJsonType{},
 				Computed:    true,
 			},
 			"rule_id": schema.StringAttribute{
@@ -110,7 +112,13 @@ func (d *ruleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	rule, _, err := d.client.GetRule(data.ActionCode.ValueString(), data.RuleId.ValueString())
+	client, diags := d.client.forTenant(data.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule, _, err := client.GetRule(ctx, data.ActionCode.ValueString(), data.RuleId.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -142,6 +150,7 @@ func (d *ruleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	ruleState := ruleDataSourceModel{
+		Tenant:                            data.Tenant,
 		Name:                              types.StringValue(rule.Name),
 		IsActive:                          types.BoolValue(rule.IsActive),
 		Priority:                          types.Int64Value(rule.Priority),
@@ -151,7 +160,7 @@ func (d *ruleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		Type:                              types.StringValue(rule.Type),
 		VerificationMethods:               verificationMethodsList,
 		PromptToEnrollVerificationMethods: promptToEnrollVerificationMethodsList,
-		Conditions:                        types.StringValue(string(conditionsJson)),
+		Conditions:                        normalizedJsonValueFrom(string(conditionsJson)),
 	}
 
 	if len(rule.Description) > 0 {
@@ -180,12 +189,12 @@ func (d *ruleDataSource) Configure(ctx context.Context, req datasource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
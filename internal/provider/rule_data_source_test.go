@@ -16,10 +16,10 @@ func TestAccRuleDataSource(t *testing.T) {
 					rule_id     = "2568fe20-851d-40f6-9c17-448dc484174c"
 				}`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					// these fields correspond to a rule that already exists in dev dynamo.
+					// these fields correspond to a rule that already exists in dev dynamo, and in the fake Management API.
 					resource.TestCheckResourceAttr("data.authsignal_rule.terraform-acc-tests", "name", "data-source-test"),
 					resource.TestCheckResourceAttr("data.authsignal_rule.terraform-acc-tests", "action_code", "terraform-acc-tests"),
-					resource.TestCheckResourceAttr("data.authsignal_rule.terraform-acc-tests", "tenant_id", "ec3910e7-ab32-479e-b58b-36a122631d58"),
+					resource.TestCheckResourceAttr("data.authsignal_rule.terraform-acc-tests", "tenant_id", testAccTenantId()),
 					resource.TestCheckResourceAttr("data.authsignal_rule.terraform-acc-tests", "description", "hello world"),
 					resource.TestCheckResourceAttr("data.authsignal_rule.terraform-acc-tests", "is_active", "false"),
 					resource.TestCheckResourceAttr("data.authsignal_rule.terraform-acc-tests", "priority", "1"),
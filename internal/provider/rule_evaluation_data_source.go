@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource                     = &ruleEvaluationDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &ruleEvaluationDataSource{}
+)
+
+func NewRuleEvaluationDataSource() datasource.DataSource {
+	return &ruleEvaluationDataSource{}
+}
+
+// ruleEvaluationDataSource evaluates rule conditions against a sample payload inside the provider.
+// It never calls the Management API, so it needs no client.
+type ruleEvaluationDataSource struct{}
+
+type ruleEvaluationDataSourceModel struct {
+	Conditions          normalizedJsonValue       `tfsdk:"conditions"`
+	Rules               []ruleEvaluationRuleModel `tfsdk:"rules"`
+	Payload             normalizedJsonValue       `tfsdk:"payload"`
+	ValueLists          map[string][]string       `tfsdk:"value_lists"`
+	DefaultType         types.String              `tfsdk:"default_type"`
+	Matched             types.Bool                `tfsdk:"matched"`
+	MatchedRuleId       types.String              `tfsdk:"matched_rule_id"`
+	Type                types.String              `tfsdk:"type"`
+	VerificationMethods types.List                `tfsdk:"verification_methods"`
+}
+
+type ruleEvaluationRuleModel struct {
+	RuleId              types.String        `tfsdk:"rule_id"`
+	Priority            types.Int64         `tfsdk:"priority"`
+	IsActive            types.Bool          `tfsdk:"is_active"`
+	Type                types.String        `tfsdk:"type"`
+	VerificationMethods types.List          `tfsdk:"verification_methods"`
+	Conditions          normalizedJsonValue `tfsdk:"conditions"`
+}
+
+func (d *ruleEvaluationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rule_evaluation"
+}
+
+func (d *ruleEvaluationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Evaluates rule conditions against a sample action payload locally, without calling the Authsignal API. Use it with `check` blocks or `terraform test` to test a risk policy before it is applied.",
+		Attributes: map[string]schema.Attribute{
+			"conditions": schema.StringAttribute{
+				Description: "A single set of JsonLogic conditions to evaluate. Exactly one of `conditions` or `rules` must be set.",
+				CustomType:  normalizedJsonType{},
+				Optional:    true,
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "The rules to evaluate, in the same shape as `authsignal_rule`. Active rules are evaluated in order of `priority`, where 0 is applied first, and the first rule whose conditions match wins. Rules with the same priority are evaluated in list order. Exactly one of `conditions` or `rules` must be set.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"rule_id": schema.StringAttribute{
+							Description: "An identifier for the rule, returned in `matched_rule_id` when the rule matches.",
+							Optional:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Determines the order which the rules are applied in, where 0 is applied first, 1 is applied second...",
+							Required:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(0, 99),
+							},
+						},
+						"is_active": schema.BoolAttribute{
+							Description: "Whether the rule is applied. Defaults to `true`.",
+							Optional:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The result that the rule should return when the conditions are met. Allowed values: `ALLOW`, `CHALLENGE`, `REVIEW`, `BLOCK`.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf([]string{"ALLOW", "CHALLENGE", "REVIEW", "BLOCK"}...),
+							},
+						},
+						"verification_methods": schema.ListAttribute{
+							ElementType: types.StringType,
+							Description: "A list of permitted authenticators that can be used if the type of the rule is 'CHALLENGE'.",
+							Optional:    true,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(stringvalidator.OneOf(allowedVerificationMethods...)),
+							},
+						},
+						"conditions": schema.StringAttribute{
+							Description: "The logical conditions to match the payload against, as a JsonLogic string.",
+							CustomType:  normalizedJsonType{},
+							Required:    true,
+						},
+					},
+				},
+			},
+			"payload": schema.StringAttribute{
+				Description: "The sample action payload the conditions are evaluated against, as a JSON object string. Use jsonencode() to build it, e.g. `jsonencode({ ip = { isAnonymous = true } })`.",
+				CustomType:  normalizedJsonType{},
+				Required:    true,
+			},
+			"value_lists": schema.MapAttribute{
+				Description: "The items of the value lists the conditions refer to, keyed by value list alias. Conditions refer to a value list as `{\"var\": \"valueLists.<alias>\"}`.",
+				ElementType: types.ListType{ElemType: types.StringType},
+				Optional:    true,
+			},
+			"default_type": schema.StringAttribute{
+				Description: "The `type` to return when no rule matches, e.g. the action configuration's `default_action_outcome`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"ALLOW", "CHALLENGE", "REVIEW", "BLOCK"}...),
+				},
+			},
+			"matched": schema.BoolAttribute{
+				Description: "Whether the conditions, or any of the active rules, matched the payload.",
+				Computed:    true,
+			},
+			"matched_rule_id": schema.StringAttribute{
+				Description: "The `rule_id` of the rule that matched, if any.",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "The `type` of the rule that matched, or `default_type` when no rule matched.",
+				Computed:    true,
+			},
+			"verification_methods": schema.ListAttribute{
+				ElementType: types.StringType,
+				Description: "The `verification_methods` of the rule that matched.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ruleEvaluationDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("conditions"),
+			path.MatchRoot("rules"),
+		),
+	}
+}
+
+func (d *ruleEvaluationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ruleEvaluationDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(data.Payload.ValueString()), &payload); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("payload"),
+			"Invalid payload",
+			"The payload must be a JSON object: "+err.Error(),
+		)
+		return
+	}
+
+	if payload == nil {
+		payload = map[string]any{}
+	}
+
+	// Value lists are looked up as `valueLists.<alias>`, so they sit alongside the payload.
+	if len(data.ValueLists) > 0 {
+		valueLists := map[string]any{}
+		for alias, items := range data.ValueLists {
+			values := make([]any, 0, len(items))
+			for _, item := range items {
+				values = append(values, item)
+			}
+			valueLists[alias] = values
+		}
+		payload["valueLists"] = valueLists
+	}
+
+	data.Matched = types.BoolValue(false)
+	data.MatchedRuleId = types.StringNull()
+	data.Type = data.DefaultType
+	data.VerificationMethods = types.ListNull(types.StringType)
+
+	if !data.Conditions.IsNull() {
+		matched, err := evaluateConditions(data.Conditions.ValueString(), payload)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("conditions"), "Unable to evaluate conditions", err.Error())
+			return
+		}
+
+		data.Matched = types.BoolValue(matched)
+	}
+
+	for _, i := range ruleEvaluationOrder(data.Rules) {
+		rule := data.Rules[i]
+
+		matched, err := evaluateConditions(rule.Conditions.ValueString(), payload)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("rules").AtListIndex(i).AtName("conditions"), "Unable to evaluate rule conditions", err.Error())
+			return
+		}
+
+		if !matched {
+			continue
+		}
+
+		data.Matched = types.BoolValue(true)
+		data.MatchedRuleId = rule.RuleId
+		data.Type = rule.Type
+		data.VerificationMethods = rule.VerificationMethods
+		break
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ruleEvaluationOrder returns the indexes of the active rules in the order they are applied: by
+// priority, and by position in the list for rules that share a priority.
+func ruleEvaluationOrder(rules []ruleEvaluationRuleModel) []int {
+	order := make([]int, 0, len(rules))
+	for i, rule := range rules {
+		if rule.IsActive.IsNull() || rule.IsActive.ValueBool() {
+			order = append(order, i)
+		}
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return rules[order[a]].Priority.ValueInt64() < rules[order[b]].Priority.ValueInt64()
+	})
+
+	return order
+}
+
+func evaluateConditions(conditions string, payload map[string]any) (bool, error) {
+	var logic any
+	if err := json.Unmarshal([]byte(conditions), &logic); err != nil {
+		return false, err
+	}
+
+	result, err := evaluateJsonLogic(logic, payload)
+	if err != nil {
+		return false, err
+	}
+
+	return jsonLogicTruthy(result), nil
+}
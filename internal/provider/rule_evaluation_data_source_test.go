@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRuleEvaluationDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "authsignal_rule_evaluation" "terraform-acc-tests" {
+					payload = jsonencode({
+						ip   = { isAnonymous = true, countryCode = "NZ" }
+						user = { email = "jane@example.com" }
+					})
+					value_lists = {
+						"blocked-emails" = ["jane@example.com"]
+					}
+					default_type = "ALLOW"
+					rules = [
+						{
+							rule_id    = "anonymous-ip"
+							priority   = 2
+							type       = "CHALLENGE"
+							verification_methods = ["EMAIL_OTP"]
+							conditions = jsonencode({ "==" : [{ "var" : "ip.isAnonymous" }, true] })
+						},
+						{
+							rule_id    = "blocked-email"
+							priority   = 1
+							type       = "BLOCK"
+							conditions = jsonencode({ "in" : [{ "var" : "user.email" }, { "var" : "valueLists.blocked-emails" }] })
+						},
+						{
+							rule_id    = "inactive"
+							priority   = 0
+							is_active  = false
+							type       = "REVIEW"
+							conditions = jsonencode({ "==" : [{ "var" : "ip.countryCode" }, "NZ"] })
+						},
+					]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.authsignal_rule_evaluation.terraform-acc-tests", "matched", "true"),
+					resource.TestCheckResourceAttr("data.authsignal_rule_evaluation.terraform-acc-tests", "matched_rule_id", "blocked-email"),
+					resource.TestCheckResourceAttr("data.authsignal_rule_evaluation.terraform-acc-tests", "type", "BLOCK"),
+					resource.TestCheckNoResourceAttr("data.authsignal_rule_evaluation.terraform-acc-tests", "verification_methods"),
+				),
+			},
+			{
+				Config: `data "authsignal_rule_evaluation" "terraform-acc-tests" {
+					payload    = jsonencode({ ip = { isAnonymous = false } })
+					conditions = jsonencode({ "==" : [{ "var" : "ip.isAnonymous" }, true] })
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.authsignal_rule_evaluation.terraform-acc-tests", "matched", "false"),
+					resource.TestCheckNoResourceAttr("data.authsignal_rule_evaluation.terraform-acc-tests", "type"),
+				),
+			},
+		},
+	})
+}
+
+func TestRuleEvaluationOrder(t *testing.T) {
+	rule := func(priority int64, isActive types.Bool) ruleEvaluationRuleModel {
+		return ruleEvaluationRuleModel{Priority: types.Int64Value(priority), IsActive: isActive}
+	}
+
+	rules := []ruleEvaluationRuleModel{
+		rule(5, types.BoolNull()),
+		rule(0, types.BoolValue(false)),
+		rule(1, types.BoolValue(true)),
+		rule(5, types.BoolNull()),
+		rule(3, types.BoolNull()),
+	}
+
+	expected := []int{2, 4, 0, 3}
+
+	order := ruleEvaluationOrder(rules)
+	if !reflect.DeepEqual(order, expected) {
+		t.Fatalf("bad order. expected: %v. got : %v", expected, order)
+	}
+}
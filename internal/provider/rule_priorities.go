@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// rulePriorityRegistry collects the priority each rule is planned with during a single Terraform run, so
+// that two rules planned onto the same action with the same priority can be reported. Terraform plans
+// every resource through the same provider instance, but one at a time and in no particular order,
+// which means a collision is reported on whichever of the two rules is planned second.
+//
+// A rule already on the tenant may still be planned later in the run, by a resource that moves it
+// away, so a collision with one is only ever a warning, even when strict.
+type rulePriorityRegistry struct {
+	mu sync.Mutex
+
+	// planned maps an action code to the rules planned on it, keyed by rule identity.
+	planned map[string]map[string]plannedRulePriority
+
+	// tenantRules caches the rules listed from each action, so the tenant is only asked once a run.
+	tenantRules map[string][]managementRule
+}
+
+type plannedRulePriority struct {
+	name     string
+	resource string
+	priority int64
+}
+
+// ruleLister lists the rules that exist on an action. It is satisfied by the provider's client in
+// normal use and by a stand-in in tests.
+type ruleLister interface {
+	ListRules(ctx context.Context, actionCode string) ([]managementRule, int, error)
+}
+
+func newRulePriorityRegistry() *rulePriorityRegistry {
+	return &rulePriorityRegistry{
+		planned:     map[string]map[string]plannedRulePriority{},
+		tenantRules: map[string][]managementRule{},
+	}
+}
+
+// plannedRule is a rule whose priority is being checked. ruleId is empty for a rule that has not been
+// created yet. resource is the type of the resource planning it, such as `authsignal_rule`, to tell the
+// user where the other rule of a collision is. owns, when set, reports the other rules that belong to
+// the same resource and so never conflict with it.
+type plannedRule struct {
+	actionCode   string
+	ruleId       string
+	name         string
+	resource     string
+	priority     int64
+	priorityPath path.Path
+	owns         func(ruleId string, name string) bool
+}
+
+func (p plannedRule) identity() string {
+	if p.ruleId != "" {
+		return "id:" + p.ruleId
+	}
+	return "name:" + p.name
+}
+
+// check registers a planned rule and reports any other rule with the same priority on the same
+// action, whether it was planned earlier in this run or already exists on the tenant. Collisions with
+// planned rules are warnings unless strict is set, and collisions with tenant rules are always warnings.
+func (r *rulePriorityRegistry) check(ctx context.Context, lister ruleLister, rule plannedRule, strict bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	planned, ok := r.planned[rule.actionCode]
+	if !ok {
+		planned = map[string]plannedRulePriority{}
+		r.planned[rule.actionCode] = planned
+	}
+	planned[rule.identity()] = plannedRulePriority{name: rule.name, resource: rule.resource, priority: rule.priority}
+
+	var conflicts, tenantConflicts []string
+
+	for _, identity := range sortedKeys(planned) {
+		other := planned[identity]
+		if identity == rule.identity() || other.priority != rule.priority {
+			continue
+		}
+
+		ruleId := ""
+		if id, ok := strings.CutPrefix(identity, "id:"); ok {
+			ruleId = id
+		}
+
+		if rule.owns != nil && rule.owns(ruleId, other.name) {
+			continue
+		}
+
+		conflicts = append(conflicts, describeRule(rule.actionCode, ruleId, other.name, other.resource)+", planned in this configuration")
+	}
+
+	tenantRules, err := r.listTenantRules(ctx, lister, rule.actionCode)
+	if err != nil {
+		diags.AddAttributeWarning(
+			rule.priorityPath,
+			"Unable to check rule priorities",
+			fmt.Sprintf("Could not list the rules on action %q to check for duplicate priorities: %s", rule.actionCode, err.Error()),
+		)
+	}
+
+	for _, tenantRule := range tenantRules {
+		if tenantRule.RuleId == rule.ruleId || tenantRule.Priority != rule.priority {
+			continue
+		}
+
+		if rule.owns != nil && rule.owns(tenantRule.RuleId, tenantRule.Name) {
+			continue
+		}
+
+		// A rule planned in this run is checked against where it is going, not where it is now.
+		if _, ok := planned["id:"+tenantRule.RuleId]; ok {
+			continue
+		}
+
+		tenantConflicts = append(tenantConflicts, describeRule(rule.actionCode, tenantRule.RuleId, tenantRule.Name, "")+", already on the tenant")
+	}
+
+	summary := "Duplicate rule priority"
+	subject := "R" + strings.TrimPrefix(describeRule(rule.actionCode, rule.ruleId, rule.name, rule.resource), "r")
+
+	if len(conflicts) > 0 {
+		detail := fmt.Sprintf(
+			"%s is planned with priority %d on action %q, which is also the priority of %s. Rules with the same priority are evaluated in an undefined order.",
+			subject, rule.priority, rule.actionCode, joinConflicts(conflicts),
+		)
+
+		if strict {
+			diags.AddAttributeError(rule.priorityPath, summary, detail)
+		} else {
+			diags.AddAttributeWarning(rule.priorityPath, summary, detail+"\n\nSet strict_rule_priorities in the provider configuration to make this an error.")
+		}
+	}
+
+	if len(tenantConflicts) > 0 {
+		diags.AddAttributeWarning(rule.priorityPath, summary, fmt.Sprintf(
+			"%s is planned with priority %d on action %q, which is also the priority of %s. Rules with the same priority are evaluated in an undefined order.\n\n"+
+				"If a resource in this configuration manages that rule and moves it to another priority, this can be ignored. "+
+				"Terraform plans resources in no particular order, so this is never an error, even with strict_rule_priorities.",
+			subject, rule.priority, rule.actionCode, joinConflicts(tenantConflicts),
+		))
+	}
+
+	return diags
+}
+
+// describeRule names a rule for a collision: by its name, by its action code and rule ID, which is
+// how an authsignal_rule is imported, and by the resource that manages it, when one does.
+func describeRule(actionCode string, ruleId string, name string, resource string) string {
+	description := fmt.Sprintf("rule %q", name)
+	if ruleId != "" {
+		description += fmt.Sprintf(" (%s/%s)", actionCode, ruleId)
+	}
+
+	switch resource {
+	case "":
+		return description
+	case "authsignal_action_rules":
+		// An action has at most one authsignal_action_rules resource, so the action code identifies it.
+		return description + fmt.Sprintf(" of the authsignal_action_rules resource for action %q", actionCode)
+	}
+
+	return description + " of an " + resource + " resource"
+}
+
+func (r *rulePriorityRegistry) listTenantRules(ctx context.Context, lister ruleLister, actionCode string) ([]managementRule, error) {
+	if rules, ok := r.tenantRules[actionCode]; ok {
+		return rules, nil
+	}
+
+	// Until the Management API client can list an action's rules, only the rules planned in this run
+	// are checked.
+	rules, statusCode, err := lister.ListRules(ctx, actionCode)
+	if statusCode == 404 || isListUnsupported(err) {
+		rules, err = nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(rules, func(a, b int) bool {
+		return rules[a].Priority < rules[b].Priority
+	})
+
+	r.tenantRules[actionCode] = rules
+	return rules, nil
+}
+
+func joinConflicts(conflicts []string) string {
+	if len(conflicts) == 1 {
+		return conflicts[0]
+	}
+
+	return strings.Join(conflicts[:len(conflicts)-1], ", ") + " and " + conflicts[len(conflicts)-1]
+}
@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+type fakeRuleLister struct {
+	rules map[string][]managementRule
+	err   error
+	calls int
+}
+
+func (l *fakeRuleLister) ListRules(ctx context.Context, actionCode string) ([]managementRule, int, error) {
+	l.calls++
+	if l.err != nil {
+		return nil, 500, l.err
+	}
+
+	rules, ok := l.rules[actionCode]
+	if !ok {
+		return nil, 404, errors.New("not found")
+	}
+
+	return rules, 200, nil
+}
+
+func TestRulePriorityCollisions(t *testing.T) {
+	tenant := func() *fakeRuleLister {
+		return &fakeRuleLister{rules: map[string][]managementRule{
+			"login": {
+				{RuleId: "existing-1", Name: "block-anonymous", Priority: 1},
+				{RuleId: "unmanaged-2", Name: "clicked-in-portal", Priority: 2},
+			},
+		}}
+	}
+
+	rule := func(ruleId string, name string, priority int64) plannedRule {
+		return plannedRule{actionCode: "login", ruleId: ruleId, name: name, priority: priority, priorityPath: path.Root("priority")}
+	}
+
+	testCases := []struct {
+		name             string
+		earlier          []plannedRule
+		rule             plannedRule
+		strict           bool
+		expectedErrors   int
+		expectedWarnings int
+	}{
+		{
+			name: "unique priority",
+			rule: rule("", "new-rule", 5),
+		},
+		{
+			name: "existing rule keeps its own priority",
+			rule: rule("existing-1", "block-anonymous", 1),
+		},
+		{
+			name:             "collides with unmanaged tenant rule",
+			rule:             rule("", "new-rule", 2),
+			expectedWarnings: 1,
+		},
+		{
+			name:           "strict makes collisions with planned rules fatal",
+			earlier:        []plannedRule{rule("", "first", 7)},
+			rule:           rule("", "second", 7),
+			strict:         true,
+			expectedErrors: 1,
+		},
+		{
+			name:             "strict leaves collisions with tenant rules as warnings",
+			rule:             rule("", "new-rule", 2),
+			strict:           true,
+			expectedWarnings: 1,
+		},
+		{
+			name:             "collides with rule planned earlier",
+			earlier:          []plannedRule{rule("", "first", 7)},
+			rule:             rule("", "second", 7),
+			expectedWarnings: 1,
+		},
+		{
+			name:    "swapped priorities",
+			earlier: []plannedRule{rule("existing-1", "block-anonymous", 2)},
+			rule:    rule("", "new-rule", 1),
+		},
+		{
+			name: "rules owned by the same resource",
+			rule: plannedRule{
+				actionCode:   "login",
+				name:         "new-rule",
+				priority:     2,
+				priorityPath: path.Root("priority"),
+				owns: func(ruleId string, name string) bool {
+					return ruleId == "unmanaged-2"
+				},
+			},
+		},
+		{
+			name: "other actions are separate",
+			rule: plannedRule{actionCode: "signup", name: "new-rule", priority: 1, priorityPath: path.Root("priority")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			registry := newRulePriorityRegistry()
+			lister := tenant()
+
+			for _, earlier := range testCase.earlier {
+				if diags := registry.check(context.Background(), lister, earlier, testCase.strict); diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+			}
+
+			diags := registry.check(context.Background(), lister, testCase.rule, testCase.strict)
+
+			if diags.ErrorsCount() != testCase.expectedErrors {
+				t.Fatalf("bad error count. expected: %v. got : %v (%v)", testCase.expectedErrors, diags.ErrorsCount(), diags)
+			}
+
+			if diags.WarningsCount() != testCase.expectedWarnings {
+				t.Fatalf("bad warning count. expected: %v. got : %v (%v)", testCase.expectedWarnings, diags.WarningsCount(), diags)
+			}
+		})
+	}
+}
+
+func TestRulePrioritySwapIsNeverFatal(t *testing.T) {
+	rules := []plannedRule{
+		{actionCode: "login", ruleId: "existing-1", name: "block-anonymous", resource: "authsignal_rule", priority: 2, priorityPath: path.Root("priority")},
+		{actionCode: "login", ruleId: "unmanaged-2", name: "clicked-in-portal", resource: "authsignal_rule", priority: 1, priorityPath: path.Root("priority")},
+	}
+
+	// Terraform plans the two rules in either order.
+	for _, order := range [][]int{{0, 1}, {1, 0}} {
+		registry := newRulePriorityRegistry()
+		lister := &fakeRuleLister{rules: map[string][]managementRule{
+			"login": {
+				{RuleId: "existing-1", Name: "block-anonymous", Priority: 1},
+				{RuleId: "unmanaged-2", Name: "clicked-in-portal", Priority: 2},
+			},
+		}}
+
+		for _, i := range order {
+			if diags := registry.check(context.Background(), lister, rules[i], true); diags.HasError() {
+				t.Fatalf("unexpected error planning %v in order %v: %v", rules[i].name, order, diags)
+			}
+		}
+	}
+}
+
+func TestRulePriorityCollisionNamesTheOtherResource(t *testing.T) {
+	registry := newRulePriorityRegistry()
+	lister := &fakeRuleLister{rules: map[string][]managementRule{"login": {}}}
+
+	registry.check(context.Background(), lister, plannedRule{actionCode: "login", ruleId: "rule-1", name: "first", resource: "authsignal_action_rules", priority: 3, priorityPath: path.Root("priority")}, true)
+	diags := registry.check(context.Background(), lister, plannedRule{actionCode: "login", name: "second", resource: "authsignal_rule", priority: 3, priorityPath: path.Root("priority")}, true)
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected one error. got : %v", diags)
+	}
+
+	expected := `Rule "second" of an authsignal_rule resource is planned with priority 3 on action "login", which is also the priority of ` +
+		`rule "first" (login/rule-1) of the authsignal_action_rules resource for action "login", planned in this configuration.`
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, expected) {
+		t.Fatalf("expected the detail to name both rules. expected: %q. got : %q", expected, detail)
+	}
+}
+
+func TestRulePriorityTenantRulesAreListedOnce(t *testing.T) {
+	registry := newRulePriorityRegistry()
+	lister := &fakeRuleLister{rules: map[string][]managementRule{"login": {}}}
+
+	for priority := int64(0); priority < 3; priority++ {
+		registry.check(context.Background(), lister, plannedRule{actionCode: "login", name: "rule", priority: priority, priorityPath: path.Root("priority")}, false)
+	}
+
+	if lister.calls != 1 {
+		t.Fatalf("bad list count. expected: %v. got : %v", 1, lister.calls)
+	}
+}
+
+func TestRulePriorityListFailureIsAWarning(t *testing.T) {
+	registry := newRulePriorityRegistry()
+	lister := &fakeRuleLister{err: errors.New("connection refused")}
+
+	diags := registry.check(context.Background(), lister, plannedRule{actionCode: "login", name: "rule", priority: 1, priorityPath: path.Root("priority")}, true)
+
+	summaries := diagnosticSummaries(diags, diag.SeverityWarning)
+	if diags.HasError() || len(summaries) != 1 || summaries[0] != "Unable to check rule priorities" {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestRulePriorityUnlistableTenantRulesAreNotChecked(t *testing.T) {
+	registry := newRulePriorityRegistry()
+	lister := &fakeRuleLister{err: listUnsupportedError{what: "the rules of an action"}}
+
+	diags := registry.check(context.Background(), lister, plannedRule{actionCode: "login", name: "rule", priority: 1, priorityPath: path.Root("priority")}, true)
+
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
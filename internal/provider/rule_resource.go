@@ -9,7 +9,9 @@ import (
 	"github.com/authsignal/authsignal-management-go/v6"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -20,9 +22,12 @@ import (
 )
 
 var (
-	_ resource.Resource                = &ruleResource{}
-	_ resource.ResourceWithConfigure   = &ruleResource{}
-	_ resource.ResourceWithImportState = &ruleResource{}
+	_ resource.Resource                     = &ruleResource{}
+	_ resource.ResourceWithConfigure        = &ruleResource{}
+	_ resource.ResourceWithImportState      = &ruleResource{}
+	_ resource.ResourceWithConfigValidators = &ruleResource{}
+	_ resource.ResourceWithValidateConfig   = &ruleResource{}
+	_ resource.ResourceWithModifyPlan       = &ruleResource{}
 )
 
 func NewRuleResource() resource.Resource {
@@ -30,22 +35,24 @@ func NewRuleResource() resource.Resource {
 }
 
 type ruleResource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 type ruleResourceModel struct {
-	Name                              types.String `tfsdk:"name"`
-	Description                       types.String `tfsdk:"description"`
-	IsActive                          types.Bool   `tfsdk:"is_active"`
-	Priority                          types.Int64  `tfsdk:"priority"`
-	ActionCode                        types.String `tfsdk:"action_code"`
-	RuleId                            types.String `tfsdk:"rule_id"`
-	TenantId                          types.String `tfsdk:"tenant_id"`
-	Type                              types.String `tfsdk:"type"`
-	VerificationMethods               types.List   `tfsdk:"verification_methods"`
-	PromptToEnrollVerificationMethods types.List   `tfsdk:"prompt_to_enroll_verification_methods"`
-	DefaultVerificationMethod         types.String `tfsdk:"default_verification_method"`
-	Conditions                        types.String `tfsdk:"conditions"`
+	Name                              types.String        `tfsdk:"name"`
+	Description                       types.String        `tfsdk:"description"`
+	IsActive                          types.Bool          `tfsdk:"is_active"`
+	Priority                          types.Int64         `tfsdk:"priority"`
+	ActionCode                        types.String        `tfsdk:"action_code"`
+	RuleId                            types.String        `tfsdk:"rule_id"`
+	TenantId                          types.String        `tfsdk:"tenant_id"`
+	Type                              types.String        `tfsdk:"type"`
+	VerificationMethods               types.List          `tfsdk:"verification_methods"`
+	PromptToEnrollVerificationMethods types.List          `tfsdk:"prompt_to_enroll_verification_methods"`
+	DefaultVerificationMethod         types.String        `tfsdk:"default_verification_method"`
+	Conditions                        normalizedJsonValue `tfsdk:"conditions"`
+	Condition                         types.Object        `tfsdk:"condition"`
+	Tenant                            types.String        `tfsdk:"tenant"`
 }
 
 func (r *ruleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -55,6 +62,7 @@ func (r *ruleResource) Metadata(_ context.Context, req resource.MetadataRequest,
 func (d *ruleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantResourceAttribute(),
 			"action_code": schema.StringAttribute{
 				Description: "The name of the action that users perform which you will track. (e.g 'login')",
 				Required:    true,
@@ -75,7 +83,7 @@ func (d *ruleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Required:    true,
 			},
 			"priority": schema.Int64Attribute{
-				Description: "Determines the order which the rules are applied in, where 0 is applied first, 1 is applied second...",
+				Description: "Determines the order which the rules are applied in, where 0 is applied first, 1 is applied second... Another rule on the same action with the same priority is reported when planning.",
 				Required:    true,
 				Validators: []validator.Int64{
 					int64validator.Between(0, 99),
@@ -112,8 +120,13 @@ func (d *ruleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"conditions": schema.StringAttribute{
-				Description: "The logical conditions to match tracked actions against. If the conditions are met then the rule's type will be returned in the track action response.",
-				Required:    true,
+				Description: "The logical conditions to match tracked actions against, as a JsonLogic string. If the conditions are met then the rule's type will be returned in the track action response. Exactly one of `conditions` or `condition` must be set; when `condition` is used this holds the JsonLogic compiled from it. Operators are checked when the configuration is validated, and any `custom.*` data points and `valueLists.*` value lists referred to are checked against the tenant at plan time.",
+				CustomType:  normalizedJsonType{},
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					conditionsFromConditionBlock{},
+				},
 			},
 			"rule_id": schema.StringAttribute{
 				Description: "The ID of the rule.",
@@ -130,10 +143,120 @@ func (d *ruleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"condition": ruleConditionBlock(),
+		},
+	}
+}
+
+func (r *ruleResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("conditions"),
+			path.MatchRoot("condition"),
+		),
+	}
+}
+
+// configuredConditions returns the rule's JsonLogic as written in the configuration, compiling the
+// `condition` block when that is what was used, along with the path to report problems against. It
+// returns an empty string when the conditions are not known yet.
+func configuredConditions(ctx context.Context, conditions normalizedJsonValue, condition types.Object) (string, path.Path, diag.Diagnostics) {
+	if !condition.IsNull() {
+		conditionsJson, diags := buildConditionsFromBlock(ctx, condition)
+		return conditionsJson, path.Root("condition"), diags
+	}
+
+	if conditions.IsNull() || conditions.IsUnknown() {
+		return "", path.Root("conditions"), nil
+	}
+
+	return conditions.ValueString(), path.Root("conditions"), nil
+}
+
+func (r *ruleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ruleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateConditionBlock(config.Condition)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conditions, conditionsPath, diags := configuredConditions(ctx, config.Conditions, config.Condition)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || conditions == "" || !json.Valid([]byte(conditions)) {
+		return
+	}
+
+	resp.Diagnostics.Append(validateConditionOperators(conditionsPath, conditions)...)
+}
+
+// ModifyPlan checks the custom data points and value lists the conditions refer to against the
+// tenant, so a type mismatch fails the plan rather than the apply and a typo shows up as a warning. It
+// also reports other rules on the action with the same priority.
+func (r *ruleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var config ruleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Tenant.IsUnknown() {
+		return
+	}
+
+	client, diags := r.client.forTenant(config.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conditions, conditionsPath, diags := configuredConditions(ctx, config.Conditions, config.Condition)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if conditions != "" {
+		resp.Diagnostics.Append(validateConditionReferences(conditionsPath, conditions, clientConditionReferenceLookup{ctx: ctx, client: client})...)
+	}
+
+	var plan ruleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ActionCode.IsUnknown() || plan.Priority.IsUnknown() {
+		return
 	}
+
+	// The rule ID is only known once the rule exists, and may not be carried into the plan.
+	var ruleId types.String
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("rule_id"), &ruleId)...)
+	}
+
+	resp.Diagnostics.Append(client.checkRulePriority(ctx, plannedRule{
+		actionCode:   plan.ActionCode.ValueString(),
+		ruleId:       ruleId.ValueString(),
+		name:         plan.Name.ValueString(),
+		resource:     "authsignal_rule",
+		priority:     plan.Priority.ValueInt64(),
+		priorityPath: path.Root("priority"),
+	})...)
 }
 
 func (r *ruleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan ruleResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -198,12 +321,20 @@ func (r *ruleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		ruleToCreate.Conditions = authsignal.SetValue(conditionsJson)
 	}
 
-	rule, _, err := r.client.CreateRule(plan.ActionCode.ValueString(), ruleToCreate)
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule, _, err := client.CreateRule(ctx, plan.ActionCode.ValueString(), ruleToCreate)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error creating rule",
-			"Could not create rule, unexpected error: "+err.Error(),
-		)
+			"Could not create rule",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 
@@ -225,7 +356,13 @@ func (r *ruleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	rule, statusCode, err := r.client.GetRule(state.ActionCode.ValueString(), state.RuleId.ValueString())
+	client, diags := r.client.forTenant(state.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule, statusCode, err := client.GetRule(ctx, state.ActionCode.ValueString(), state.RuleId.ValueString())
 
 	if statusCode == 404 {
 		resp.State.RemoveResource(ctx)
@@ -283,9 +420,9 @@ func (r *ruleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	if rule.Conditions != nil {
-		state.Conditions = types.StringValue(string(conditionsJson))
+		state.Conditions = normalizedJsonValueFrom(string(conditionsJson))
 	} else {
-		state.Conditions = types.StringNull()
+		state.Conditions = normalizedJsonNull()
 	}
 
 	diags = resp.State.Set(ctx, &state)
@@ -296,6 +433,11 @@ func (r *ruleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *ruleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan ruleResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -373,16 +515,24 @@ func (r *ruleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		ruleToUpdate.Conditions = authsignal.SetNull(conditionsJson)
 	}
 
-	_, _, err := r.client.UpdateRule(plan.ActionCode.ValueString(), plan.RuleId.ValueString(), ruleToUpdate)
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, _, err := client.UpdateRule(ctx, plan.ActionCode.ValueString(), plan.RuleId.ValueString(), ruleToUpdate)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error Updating Authsignal rule",
-			"Could not update rule, unexpected error: "+err.Error(),
-		)
+			"Could not update rule",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 
-	updatedRule, _, err := r.client.GetRule(plan.ActionCode.ValueString(), plan.RuleId.ValueString())
+	updatedRule, _, err := client.GetRule(ctx, plan.ActionCode.ValueString(), plan.RuleId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Authsignal rule",
@@ -402,6 +552,11 @@ func (r *ruleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *ruleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state ruleResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -409,23 +564,31 @@ func (r *ruleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	_, _, err := r.client.DeleteRule(state.ActionCode.ValueString(), state.RuleId.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
+	client, diags := r.client.forTenant(state.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, statusCode, err := client.DeleteRule(ctx, state.ActionCode.ValueString(), state.RuleId.ValueString())
+	if err != nil && statusCode != 404 {
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error Deleting Authsignal rule",
-			"Could not delete rule, unexpected error: "+err.Error(),
-		)
+			"Could not delete rule",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 }
 
 func (r *ruleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	ruleIdentifiers := strings.Split(req.ID, "/")
+	ruleIdentifiers := strings.Split(r.client.importTenant(ctx, req, resp), "/")
 
 	if len(ruleIdentifiers) != 2 || ruleIdentifiers[0] == "" || ruleIdentifiers[1] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: action_code/rule_id. Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: action_code/rule_id or tenant:action_code/rule_id. Got: %q", req.ID),
 		)
 		return
 	}
@@ -439,12 +602,12 @@ func (r *ruleResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -43,7 +43,7 @@ func TestAccRuleResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "name", "create-rule-test"),
 					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "action_code", "terraform-acc-tests"),
-					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "tenant_id", "ec3910e7-ab32-479e-b58b-36a122631d58"),
+					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "tenant_id", testAccTenantId()),
 					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "description", "hello world"),
 					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "is_active", "false"),
 					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "priority", "2"),
@@ -89,7 +89,7 @@ func TestAccRuleResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "name", "update-rule-test"),
 					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "action_code", "terraform-acc-tests"),
-					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "tenant_id", "ec3910e7-ab32-479e-b58b-36a122631d58"),
+					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "tenant_id", testAccTenantId()),
 					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "description", "hello world"),
 					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "is_active", "false"),
 					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests", "priority", "3"),
@@ -106,3 +106,106 @@ func TestAccRuleResource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccRuleResourceConditionBlock(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+				resource "authsignal_rule" "terraform-acc-tests-condition-block" {
+					action_code = "terraform-acc-tests"
+					name        = "condition-block-test"
+					priority    = 4
+					type        = "BLOCK"
+					is_active   = false
+					condition {
+						all {
+							match {
+								var        = "ip.isAnonymous"
+								op         = "=="
+								bool_value = false
+							}
+						}
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests-condition-block", "name", "condition-block-test"),
+					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests-condition-block", "conditions", `{"and":[{"==":[{"var":"ip.isAnonymous"},false]}]}`),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: `
+				resource "authsignal_rule" "terraform-acc-tests-condition-block" {
+					action_code = "terraform-acc-tests"
+					name        = "condition-block-test"
+					priority    = 4
+					type        = "BLOCK"
+					is_active   = false
+					condition {
+						any {
+							match {
+								var        = "ip.isAnonymous"
+								op         = "=="
+								bool_value = true
+							}
+							match {
+								var          = "custom.riskScore"
+								op           = ">"
+								number_value = 80
+							}
+						}
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests-condition-block", "conditions", `{"or":[{"==":[{"var":"ip.isAnonymous"},true]},{"\u003e":[{"var":"custom.riskScore"},80]}]}`),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccRuleResourceReferencesCreatedInSameApply(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The rule refers to the value list by its alias before the value list exists
+			{
+				Config: `
+				resource "authsignal_value_list" "terraform-acc-tests-same-apply" {
+					name                     = "Terraform Acc Tests Same Apply"
+					is_active                = true
+					value_list_items_strings = ["blocked@example.com"]
+				}
+
+				resource "authsignal_rule" "terraform-acc-tests-same-apply" {
+					action_code = "terraform-acc-tests"
+					name        = "same-apply-test"
+					priority    = 5
+					type        = "BLOCK"
+					is_active   = false
+					condition {
+						all {
+							match {
+								var        = "user.email"
+								op         = "in"
+								value_list = "terraform-acc-tests-same-apply"
+							}
+						}
+					}
+
+					depends_on = [authsignal_value_list.terraform-acc-tests-same-apply]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_rule.terraform-acc-tests-same-apply", "conditions", `{"and":[{"in":[{"var":"user.email"},{"var":"valueLists.terraform-acc-tests-same-apply"}]}]}`),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &rulesDataSource{}
+	_ datasource.DataSourceWithConfigure = &rulesDataSource{}
+)
+
+func NewRulesDataSource() datasource.DataSource {
+	return &rulesDataSource{}
+}
+
+type rulesDataSource struct {
+	client *authsignalClient
+}
+
+type rulesDataSourceModel struct {
+	ActionCode types.String          `tfsdk:"action_code"`
+	Type       types.String          `tfsdk:"type"`
+	IsActive   types.Bool            `tfsdk:"is_active"`
+	Rules      []ruleDataSourceModel `tfsdk:"rules"`
+	Tenant     types.String          `tfsdk:"tenant"`
+}
+
+func (d *rulesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rules"
+}
+
+func (d *rulesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the rules on a tenant, optionally filtered by action, type and whether they are active. Reading it fails until the Management API client can list them.",
+		Attributes: map[string]schema.Attribute{
+			"tenant": tenantDataSourceAttribute(),
+			"action_code": schema.StringAttribute{
+				Description: "Only list the rules of this action. When omitted, the rules of every action are listed.",
+				Optional:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Only list rules that return this result. (e.g. ALLOW, CHALLENGE)",
+				Optional:    true,
+			},
+			"is_active": schema.BoolAttribute{
+				Description: "Only list rules that are, or are not, actively applied.",
+				Optional:    true,
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "The matching rules, ordered by action code and then by priority.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tenant": tenantListedAttribute(),
+						"action_code": schema.StringAttribute{
+							Description: "The name of the action that users perform which you will track. (e.g 'login')",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "A string used to name the rule.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "A description of the rule.",
+							Computed:    true,
+						},
+						"is_active": schema.BoolAttribute{
+							Description: "Toggles whether or not the rule is actively applied.",
+							Computed:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Determines the order which the rules are applied in, where 0 is applied first, 1 is applied second...",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The result that the rule should return when the conditions are met. (e.g. ALLOW, CHALLENGE)",
+							Computed:    true,
+						},
+						"verification_methods": schema.ListAttribute{
+							ElementType: types.StringType,
+							Description: "A list of permitted authenticators that can be used if the type of the rule is 'CHALLENGE'.",
+							Computed:    true,
+						},
+						"prompt_to_enroll_verification_methods": schema.ListAttribute{
+							ElementType: types.StringType,
+							Description: "If this is set then users will be prompted to add a passkey after a challenge is completed.",
+							Computed:    true,
+						},
+						"default_verification_method": schema.StringAttribute{
+							Description: "Ignore the user's preference and choose which authenticator the Pre-built UI will present by default.",
+							Computed:    true,
+						},
+						"conditions": schema.StringAttribute{
+							Description: "The logical conditions to match tracked actions against. If the conditions are met then the rule's type will be returned in the track action response.",
+							CustomType:  normalizedJsonType{},
+							Computed:    true,
+						},
+						"rule_id": schema.StringAttribute{
+							Description: "The ID of the rule.",
+							Computed:    true,
+						},
+						"tenant_id": schema.StringAttribute{
+							Description: "The ID of your tenant. This can be found in the admin portal.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *rulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data rulesDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.client.forTenant(data.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actionCodes := []string{data.ActionCode.ValueString()}
+
+	if data.ActionCode.IsNull() {
+		actionConfigurations, _, err := client.ListActionConfigurations(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to List Authsignal ActionConfigurations", err.Error())
+			return
+		}
+
+		actionCodes = make([]string, 0, len(actionConfigurations))
+		for _, actionConfiguration := range actionConfigurations {
+			actionCodes = append(actionCodes, actionConfiguration.ActionCode)
+		}
+		sort.Strings(actionCodes)
+	}
+
+	data.Rules = []ruleDataSourceModel{}
+
+	for _, actionCode := range actionCodes {
+		rules, statusCode, err := client.ListRules(ctx, actionCode)
+
+		// An action with no rules may not be known to the rules endpoint yet.
+		if statusCode == 404 {
+			continue
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to List Authsignal Rules", err.Error())
+			return
+		}
+
+		for _, rule := range filterRules(rules, data.Type, data.IsActive) {
+			if rule.ActionCode == "" {
+				rule.ActionCode = actionCode
+			}
+
+			ruleState, diags := ruleDataSourceModelFromManagementRule(ctx, rule)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			ruleState.Tenant = data.Tenant
+			data.Rules = append(data.Rules, ruleState)
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// filterRules returns the rules matching the type and is_active filters, which match everything when
+// null, ordered by priority.
+func filterRules(rules []managementRule, ruleType types.String, isActive types.Bool) []managementRule {
+	filtered := []managementRule{}
+
+	for _, rule := range rules {
+		if !ruleType.IsNull() && rule.Type != ruleType.ValueString() {
+			continue
+		}
+
+		if !isActive.IsNull() && rule.IsActive != isActive.ValueBool() {
+			continue
+		}
+
+		filtered = append(filtered, rule)
+	}
+
+	sort.SliceStable(filtered, func(a, b int) bool {
+		return filtered[a].Priority < filtered[b].Priority
+	})
+
+	return filtered
+}
+
+func ruleDataSourceModelFromManagementRule(ctx context.Context, rule managementRule) (ruleDataSourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	verificationMethodsList, d := types.ListValueFrom(ctx, types.StringType, rule.VerificationMethods)
+	diags.Append(d...)
+
+	promptToEnrollVerificationMethodsList, d := types.ListValueFrom(ctx, types.StringType, rule.PromptToEnrollVerificationMethods)
+	diags.Append(d...)
+
+	conditionsJson, err := json.Marshal(rule.Conditions)
+	if err != nil {
+		diags.AddError("Unable to marshall conditions", err.Error())
+	}
+
+	if diags.HasError() {
+		return ruleDataSourceModel{}, diags
+	}
+
+	ruleState := ruleDataSourceModel{
+		Name:                              types.StringValue(rule.Name),
+		IsActive:                          types.BoolValue(rule.IsActive),
+		Priority:                          types.Int64Value(rule.Priority),
+		ActionCode:                        types.StringValue(rule.ActionCode),
+		RuleId:                            types.StringValue(rule.RuleId),
+		TenantId:                          types.StringValue(rule.TenantId),
+		Type:                              types.StringValue(rule.Type),
+		VerificationMethods:               verificationMethodsList,
+		PromptToEnrollVerificationMethods: promptToEnrollVerificationMethodsList,
+		Conditions:                        normalizedJsonValueFrom(string(conditionsJson)),
+	}
+
+	if len(rule.Description) > 0 {
+		ruleState.Description = types.StringValue(rule.Description)
+	} else {
+		ruleState.Description = types.StringNull()
+	}
+
+	if len(rule.DefaultVerificationMethod) > 0 {
+		ruleState.DefaultVerificationMethod = types.StringValue(rule.DefaultVerificationMethod)
+	} else {
+		ruleState.DefaultVerificationMethod = types.StringNull()
+	}
+
+	return ruleState, diags
+}
+
+func (d *rulesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring Authsignal client")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*authsignalClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
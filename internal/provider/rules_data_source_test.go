@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRulesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The Management API client cannot list rules yet, so reading the data source fails.
+			{
+				Config: `data "authsignal_rules" "terraform-acc-tests" {
+					action_code = "terraform-acc-tests"
+					type        = "CHALLENGE"
+				}`,
+				ExpectError: regexp.MustCompile("has no call to list"),
+			},
+		},
+	})
+}
+
+func TestFilterRules(t *testing.T) {
+	rules := []managementRule{
+		{Name: "c", Type: "BLOCK", IsActive: true, Priority: 2},
+		{Name: "a", Type: "CHALLENGE", IsActive: true, Priority: 0},
+		{Name: "b", Type: "CHALLENGE", IsActive: false, Priority: 1},
+	}
+
+	testCases := []struct {
+		name          string
+		ruleType      types.String
+		isActive      types.Bool
+		expectedNames []string
+	}{
+		{
+			name:          "no filters",
+			ruleType:      types.StringNull(),
+			isActive:      types.BoolNull(),
+			expectedNames: []string{"a", "b", "c"},
+		},
+		{
+			name:          "type",
+			ruleType:      types.StringValue("CHALLENGE"),
+			isActive:      types.BoolNull(),
+			expectedNames: []string{"a", "b"},
+		},
+		{
+			name:          "inactive",
+			ruleType:      types.StringNull(),
+			isActive:      types.BoolValue(false),
+			expectedNames: []string{"b"},
+		},
+		{
+			name:          "type and active",
+			ruleType:      types.StringValue("BLOCK"),
+			isActive:      types.BoolValue(true),
+			expectedNames: []string{"c"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			names := []string{}
+			for _, rule := range filterRules(rules, testCase.ruleType, testCase.isActive) {
+				names = append(names, rule.Name)
+			}
+
+			if len(names) != len(testCase.expectedNames) {
+				t.Fatalf("bad rules. expected: %v. got : %v", testCase.expectedNames, names)
+			}
+
+			for i := range names {
+				if names[i] != testCase.expectedNames[i] {
+					t.Fatalf("bad rules. expected: %v. got : %v", testCase.expectedNames, names)
+				}
+			}
+		})
+	}
+}
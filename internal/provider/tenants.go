@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// A provider can manage several tenants: its own, configured with host, tenant_id and api_secret, and
+// any number of others in `tenants`. Every resource and data source has a `tenant` attribute naming
+// one of the others, and uses the provider's own tenant when it is not set.
+
+// tenantImportSeparator separates the tenant from the rest of an import ID, as in `staging:login`.
+const tenantImportSeparator = ":"
+
+func tenantResourceAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "The key of the tenant in the provider's `tenants` that this resource belongs to. Defaults to the provider's own tenant. Changing it replaces the resource.",
+		Optional:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+func tenantDataSourceAttribute() datasourceschema.StringAttribute {
+	return datasourceschema.StringAttribute{
+		Description: "The key of the tenant in the provider's `tenants` to read from. Defaults to the provider's own tenant.",
+		Optional:    true,
+	}
+}
+
+// tenantListedAttribute is the `tenant` of each item a plural data source lists. Items share the
+// model of the singular data source, so they carry its `tenant` too.
+func tenantListedAttribute() datasourceschema.StringAttribute {
+	return datasourceschema.StringAttribute{
+		Description: "The key of the tenant in the provider's `tenants` this was read from, the same as the data source's `tenant`.",
+		Computed:    true,
+	}
+}
+
+// forTenant returns the client for a resource's `tenant` attribute.
+func (c *authsignalClient) forTenant(tenant types.String) (*authsignalClient, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if tenant.IsNull() || tenant.IsUnknown() {
+		if !c.hasOwnTenant() {
+			diags.AddAttributeError(
+				path.Root("tenant"),
+				"Missing Authsignal Tenant",
+				"The provider has no tenant of its own configured, only `tenants`, so the tenant attribute must be set.",
+			)
+			return nil, diags
+		}
+
+		return c, diags
+	}
+
+	client, ok := c.tenants[tenant.ValueString()]
+	if !ok {
+		diags.AddAttributeError(
+			path.Root("tenant"),
+			"Unknown Authsignal Tenant",
+			fmt.Sprintf("The provider has no tenant %q. Configured tenants: %s.", tenant.ValueString(), strings.Join(sortedKeys(c.tenants), ", ")),
+		)
+		return nil, diags
+	}
+
+	return client, diags
+}
+
+// splitTenantImportId splits an import ID of the form `<tenant>:<id>` into the tenant and the ID the
+// resource is imported by. An ID without a configured tenant before the separator belongs to the
+// provider's own tenant.
+func (c *authsignalClient) splitTenantImportId(importId string) (types.String, string) {
+	tenant, id, ok := strings.Cut(importId, tenantImportSeparator)
+	if ok && c != nil {
+		if _, configured := c.tenants[tenant]; configured {
+			return types.StringValue(tenant), id
+		}
+	}
+
+	return types.StringNull(), importId
+}
+
+// importTenant sets the `tenant` attribute from an import ID and returns the rest of the ID.
+func (c *authsignalClient) importTenant(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) string {
+	tenant, id := c.splitTenantImportId(req.ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+	return id
+}
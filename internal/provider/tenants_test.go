@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestForTenant(t *testing.T) {
+	client := newAuthsignalClient("https://api.authsignal.com/v1/management", "production", "secret")
+	staging := newAuthsignalClient("https://api.authsignal.com/v1/management", "staging", "secret")
+	client.tenants = map[string]*authsignalClient{"staging": staging}
+
+	if got, diags := client.forTenant(types.StringNull()); diags.HasError() || got != client {
+		t.Fatalf("expected the provider's own tenant for a null tenant. got: %v, %v", got, diags)
+	}
+
+	if got, diags := client.forTenant(types.StringValue("staging")); diags.HasError() || got != staging {
+		t.Fatalf("expected the staging tenant. got: %v, %v", got, diags)
+	}
+
+	if _, diags := client.forTenant(types.StringValue("production")); !diags.HasError() {
+		t.Fatalf("expected an error for a tenant that is not configured")
+	}
+
+	tenantsOnly := &authsignalClient{tenants: client.tenants}
+	if _, diags := tenantsOnly.forTenant(types.StringNull()); !diags.HasError() {
+		t.Fatalf("expected an error for a null tenant without a tenant of the provider's own")
+	}
+}
+
+func TestSplitTenantImportId(t *testing.T) {
+	client := newAuthsignalClient("https://api.authsignal.com/v1/management", "production", "secret")
+	client.tenants = map[string]*authsignalClient{"staging": {}}
+
+	testCases := []struct {
+		importId string
+		tenant   types.String
+		id       string
+	}{
+		{importId: "login/a2d9670f", tenant: types.StringNull(), id: "login/a2d9670f"},
+		{importId: "staging:login/a2d9670f", tenant: types.StringValue("staging"), id: "login/a2d9670f"},
+		{importId: "staging:", tenant: types.StringValue("staging"), id: ""},
+		{importId: "other:login", tenant: types.StringNull(), id: "other:login"},
+		{importId: "", tenant: types.StringNull(), id: ""},
+	}
+
+	for _, testCase := range testCases {
+		tenant, id := client.splitTenantImportId(testCase.importId)
+
+		if !tenant.Equal(testCase.tenant) || id != testCase.id {
+			t.Fatalf("bad split of %q. expected: %v, %q. got : %v, %q", testCase.importId, testCase.tenant, testCase.id, tenant, id)
+		}
+	}
+}
+
+func TestConfigureTenants(t *testing.T) {
+	ctx := context.Background()
+	tenantType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"host":       types.StringType,
+		"region":     types.StringType,
+		"tenant_id":  types.StringType,
+		"api_secret": types.StringType,
+	}}
+
+	tenant := func(host types.String, tenantId string, apiSecret string) attr.Value {
+		return types.ObjectValueMust(tenantType.AttrTypes, map[string]attr.Value{
+			"host":       host,
+			"region":     types.StringNull(),
+			"tenant_id":  types.StringValue(tenantId),
+			"api_secret": types.StringValue(apiSecret),
+		})
+	}
+
+	value := types.MapValueMust(tenantType, map[string]attr.Value{
+		"staging": tenant(types.StringNull(), "staging-id", "staging-secret"),
+		"au":      tenant(types.StringValue("https://au.authsignal.com/v1/management"), "au-id", "au-secret"),
+	})
+
+	tenants, diags := configureTenants(ctx, value, "https://api.authsignal.com/v1/management")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := tenants["staging"].Host.ValueString(); got != "https://api.authsignal.com/v1/management" {
+		t.Fatalf("expected the staging tenant to default to the provider's host. got: %v", got)
+	}
+
+	if got := tenants["au"].Host.ValueString(); got != "https://au.authsignal.com/v1/management" {
+		t.Fatalf("expected the au tenant to keep its own host. got: %v", got)
+	}
+
+	_, diags = configureTenants(ctx, value, "")
+	if !diags.HasError() {
+		t.Fatalf("expected an error for a tenant without a host")
+	}
+
+	missingSecret := types.MapValueMust(tenantType, map[string]attr.Value{
+		"staging": tenant(types.StringNull(), "staging-id", ""),
+	})
+	if _, diags := configureTenants(ctx, missingSecret, "https://api.authsignal.com/v1/management"); !diags.HasError() {
+		t.Fatalf("expected an error for a tenant without an api_secret")
+	}
+}
@@ -4,9 +4,10 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/authsignal/authsignal-management-go/v6"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -20,7 +21,7 @@ func NewThemeDataSource() datasource.DataSource {
 }
 
 type themeDataSource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 func (d *themeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -56,6 +57,7 @@ func typefaceDataSourceAttributes() map[string]schema.Attribute {
 func (d *themeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantDataSourceAttribute(),
 			"name": schema.StringAttribute{
 				Description: "The name of the tenant which is visible to users.",
 				Computed:    true,
@@ -407,7 +409,20 @@ func (d *themeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 }
 
 func (d *themeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	theme, _, err := d.client.GetTheme()
+	var tenant types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("tenant"), &tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.client.forTenant(tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	theme, _, err := client.GetTheme(ctx)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -419,8 +434,9 @@ func (d *themeDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	var themeState themeModel
 	themeState.CreateObject(*theme)
+	themeState.Tenant = tenant
 
-	diags := resp.State.Set(ctx, &themeState)
+	diags = resp.State.Set(ctx, &themeState)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
@@ -435,12 +451,12 @@ func (d *themeDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
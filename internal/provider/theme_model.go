@@ -21,6 +21,7 @@ type themeModel struct {
 	Links          types.Object `tfsdk:"links"`
 	Shadows        types.Object `tfsdk:"shadows"`
 	PageBackground types.Object `tfsdk:"page_background"`
+	Tenant         types.String `tfsdk:"tenant"`
 }
 
 func (m *themeModel) CreateObject(input authsignal.ThemeResponse) types.Object {
@@ -97,6 +98,7 @@ func (m themeModel) AttributeTypes() map[string]attr.Type {
 		"links":           types.ObjectType{AttrTypes: linksModel{}.AttributeTypes()},
 		"shadows":         types.ObjectType{AttrTypes: shadowsModel{}.AttributeTypes()},
 		"page_background": types.ObjectType{AttrTypes: pageBackgroundModel{}.AttributeTypes()},
+		"tenant":          types.StringType,
 	}
 }
 
@@ -115,6 +117,7 @@ func (m themeModel) AttributeValues() map[string]attr.Value {
 	elements["shadows"] = m.Shadows
 	elements["page_background"] = m.PageBackground
 	elements["dark_mode"] = m.DarkMode
+	elements["tenant"] = m.Tenant
 
 	return elements
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
@@ -61,7 +62,7 @@ func NewThemeResource() resource.Resource {
 }
 
 type themeResource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 func (r *themeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -71,6 +72,7 @@ func (r *themeResource) Metadata(_ context.Context, req resource.MetadataRequest
 func (r *themeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantResourceAttribute(),
 			"name": schema.StringAttribute{
 				Description: "The name of the tenant which is visible to users.",
 				Required:    true,
@@ -454,6 +456,11 @@ func (r *themeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 }
 
 func (r *themeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.AddError(
 		"Please import the existing theme via `terraform import ...`",
 		"Themes cannot be created via Terraform, only updated. Please import the existing theme through `terraform import ...` and then try applying again.",
@@ -461,7 +468,20 @@ func (r *themeResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *themeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	theme, statusCode, err := r.client.GetTheme()
+	var tenant types.String
+	diags := req.State.GetAttribute(ctx, path.Root("tenant"), &tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.forTenant(tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	theme, statusCode, err := client.GetTheme(ctx)
 
 	if statusCode == 404 {
 		resp.State.RemoveResource(ctx)
@@ -478,8 +498,9 @@ func (r *themeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	var themeState themeModel
 	themeState.CreateObject(*theme)
+	themeState.Tenant = tenant
 
-	diags := resp.State.Set(ctx, &themeState)
+	diags = resp.State.Set(ctx, &themeState)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
@@ -488,6 +509,11 @@ func (r *themeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *themeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan themeModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -495,19 +521,28 @@ func (r *themeResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var themeToCreate = buildAuthsignalThemeUpdateObject(ctx, resp, plan)
 
-	theme, _, err := r.client.UpdateTheme(themeToCreate)
+	theme, _, err := client.UpdateTheme(ctx, themeToCreate)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error updating theme",
-			"Could not create theme, unexpected error: "+err.Error(),
-		)
+			"Could not create theme",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 
 	var themeState themeModel
 	themeState.CreateObject(*theme)
+	themeState.Tenant = plan.Tenant
 
 	diags = resp.State.Set(ctx, themeState)
 	resp.Diagnostics.Append(diags...)
@@ -517,6 +552,11 @@ func (r *themeResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *themeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state themeModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -524,14 +564,22 @@ func (r *themeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	client, diags := r.client.forTenant(state.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var themeToCreate = buildAuthsignalThemeDeleteObject(state)
 
-	_, _, err := r.client.UpdateTheme(themeToCreate)
+	_, _, err := client.UpdateTheme(ctx, themeToCreate)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error deleting theme",
-			"Could not create theme, unexpected error: "+err.Error(),
-		)
+			"Could not create theme",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 
@@ -541,7 +589,8 @@ func (r *themeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *themeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	name := r.client.importTenant(ctx, req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
 }
 
 func (r *themeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -549,12 +598,12 @@ func (r *themeResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -0,0 +1,300 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryMaxWait = 30 * time.Second
+
+	// retryBaseWait is the wait before the first retry. It doubles with each retry after that.
+	retryBaseWait = 500 * time.Millisecond
+)
+
+// baseTransport is a copy of http.DefaultTransport, which each provider configuration's transports
+// wrap a copy of, so that no configuration shares its connections, proxy or certificates with another.
+var baseTransport = http.DefaultTransport.(*http.Transport).Clone()
+
+// newBaseTransport returns a copy of baseTransport that connects through proxy, when it is set, and
+// trusts rootCAs, when they are set. Without a proxy, the usual HTTPS_PROXY and NO_PROXY environment
+// variables still apply.
+func newBaseTransport(proxy *url.URL, rootCAs *x509.CertPool, insecureSkipVerify bool) *http.Transport {
+	transport := baseTransport.Clone()
+
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if rootCAs != nil || insecureSkipVerify {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+		transport.TLSClientConfig.InsecureSkipVerify = insecureSkipVerify
+	}
+
+	return transport
+}
+
+// loadRootCAs returns the system's trusted certificates plus those in caCertFile and caCertPem, or
+// nil when neither is set.
+func loadRootCAs(caCertFile string, caCertPem string) (*x509.CertPool, error) {
+	if caCertFile == "" && caCertPem == "" {
+		return nil, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if caCertFile != "" {
+		pem, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in %s", caCertFile)
+		}
+	}
+
+	if caCertPem != "" && !pool.AppendCertsFromPEM([]byte(caCertPem)) {
+		return nil, errors.New("no PEM encoded certificates found in ca_cert_pem")
+	}
+
+	return pool, nil
+}
+
+// timeoutTransport bounds how long each request may take, from sending it to reading the last of
+// the response body. Sitting inside retryTransport, it bounds each attempt rather than the total.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	res, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// cancelOnClose releases a request's context once its response body has been read.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// retryTransport retries requests that fail with a rate limit, a server error or a network error,
+// waiting with jittered exponential backoff between attempts, or as long as a Retry-After header asks.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+
+	// sleep waits between attempts. It is replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		maxWait:    maxWait,
+		sleep:      sleepContext,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	// The body is replayed on every attempt, so it is read up front if it can't be got again.
+	if req.Body != nil && req.GetBody == nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		req = req.Clone(ctx)
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		req.Body, _ = req.GetBody()
+	}
+
+	for attempt := 0; ; attempt++ {
+		res, err := t.next.RoundTrip(req)
+
+		if attempt >= t.maxRetries || !isRetryable(req, res, err) {
+			return res, err
+		}
+
+		wait, ok := t.retryWait(res, attempt)
+		if !ok {
+			return res, err
+		}
+
+		fields := map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status_code"] = res.StatusCode
+		}
+		tflog.Debug(ctx, "Retrying Authsignal API request", fields)
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		if err := t.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
+// isRetryable reports whether a failed attempt can safely be sent again. A 429 means the request was
+// not processed, and a failure to connect means it was never sent, so any request can be retried
+// after either. Other server and network errors may have happened after the request was processed,
+// so only reads are retried after them: a create, update or delete sent again could act twice, or
+// fail because the first attempt already took effect.
+func isRetryable(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err == nil && res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if err != nil && isConnectError(err) {
+		return true
+	}
+
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	switch res.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// isConnectError reports whether err is a failure to connect to the Management API, before any of
+// the request was sent.
+func isConnectError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryWait returns how long to wait before the next attempt. A Retry-After header is honoured as
+// long as it is within maxWait; when it asks for longer, there is no point retrying sooner, so ok is
+// false. Otherwise the wait is a jittered exponential backoff, capped at maxWait.
+func (t *retryTransport) retryWait(res *http.Response, attempt int) (time.Duration, bool) {
+	if res != nil {
+		if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return retryAfter, retryAfter <= t.maxWait
+		}
+	}
+
+	backoff := retryBaseWait << attempt
+	if backoff > t.maxWait || backoff <= 0 {
+		backoff = t.maxWait
+	}
+
+	// Jitter between half the backoff and all of it, so that parallel requests spread out.
+	half := backoff / 2
+	if half <= 0 {
+		return backoff, true
+	}
+
+	return half + time.Duration(rand.Int63n(int64(half)+1)), true
+}
+
+// parseRetryAfter reads a Retry-After header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,441 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// flakyServer fails the first len(failures) requests with the given status codes, then succeeds. It
+// records the body of every request it receives.
+func flakyServer(t *testing.T, failures []int, header http.Header) (*httptest.Server, *int32, *[]string) {
+	var requests int32
+	bodies := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if int(n) <= len(failures) {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(failures[n-1])
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests, &bodies
+}
+
+func testRetryTransport(maxRetries int, maxWait time.Duration) (*retryTransport, *[]time.Duration) {
+	waits := []time.Duration{}
+
+	transport := newRetryTransport(http.DefaultTransport, maxRetries, maxWait)
+	transport.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	return transport, &waits
+}
+
+func TestRetryTransport(t *testing.T) {
+	testCases := []struct {
+		name             string
+		method           string
+		failures         []int
+		header           http.Header
+		maxRetries       int
+		expectedStatus   int
+		expectedRequests int32
+	}{
+		{
+			name:             "retries server errors",
+			method:           http.MethodGet,
+			failures:         []int{503, 500, 502},
+			maxRetries:       3,
+			expectedStatus:   200,
+			expectedRequests: 4,
+		},
+		{
+			name:             "gives up after max retries",
+			method:           http.MethodGet,
+			failures:         []int{503, 503, 503},
+			maxRetries:       2,
+			expectedStatus:   503,
+			expectedRequests: 3,
+		},
+		{
+			name:             "does not retry client errors",
+			method:           http.MethodGet,
+			failures:         []int{404},
+			maxRetries:       3,
+			expectedStatus:   404,
+			expectedRequests: 1,
+		},
+		{
+			name:             "does not retry a create that failed on the server",
+			method:           http.MethodPost,
+			failures:         []int{500},
+			maxRetries:       3,
+			expectedStatus:   500,
+			expectedRequests: 1,
+		},
+		{
+			name:             "does not retry an update that failed on the server",
+			method:           http.MethodPatch,
+			failures:         []int{503},
+			maxRetries:       3,
+			expectedStatus:   503,
+			expectedRequests: 1,
+		},
+		{
+			name:             "does not retry a delete that failed on the server",
+			method:           http.MethodDelete,
+			failures:         []int{502},
+			maxRetries:       3,
+			expectedStatus:   502,
+			expectedRequests: 1,
+		},
+		{
+			name:             "retries a rate limited delete",
+			method:           http.MethodDelete,
+			failures:         []int{429},
+			maxRetries:       3,
+			expectedStatus:   200,
+			expectedRequests: 2,
+		},
+		{
+			name:             "retries a rate limited create",
+			method:           http.MethodPost,
+			failures:         []int{429},
+			maxRetries:       3,
+			expectedStatus:   200,
+			expectedRequests: 2,
+		},
+		{
+			name:             "retries disabled",
+			method:           http.MethodGet,
+			failures:         []int{429},
+			maxRetries:       0,
+			expectedStatus:   429,
+			expectedRequests: 1,
+		},
+		{
+			name:             "does not wait longer than max wait",
+			method:           http.MethodGet,
+			failures:         []int{429},
+			header:           http.Header{"Retry-After": []string{"120"}},
+			maxRetries:       3,
+			expectedStatus:   429,
+			expectedRequests: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server, requests, _ := flakyServer(t, testCase.failures, testCase.header)
+			transport, _ := testRetryTransport(testCase.maxRetries, 30*time.Second)
+
+			req, _ := http.NewRequest(testCase.method, server.URL, nil)
+			res, err := (&http.Client{Transport: transport}).Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			res.Body.Close()
+
+			if res.StatusCode != testCase.expectedStatus {
+				t.Fatalf("bad status. expected: %v. got : %v", testCase.expectedStatus, res.StatusCode)
+			}
+
+			if *requests != testCase.expectedRequests {
+				t.Fatalf("bad request count. expected: %v. got : %v", testCase.expectedRequests, *requests)
+			}
+		})
+	}
+}
+
+func TestRetryTransportRetriesUpdatesThatCouldNotConnect(t *testing.T) {
+	attempts := 0
+	transport, _ := testRetryTransport(3, 30*time.Second)
+	transport.next = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+		}
+		if attempts == 2 {
+			return nil, &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	req, _ := http.NewRequest(http.MethodPatch, "https://api.authsignal.com/v1/management/theme", strings.NewReader(`{}`))
+	_, err := transport.RoundTrip(req)
+
+	// The failure to connect is retried, but not the reset, which may have come after the update.
+	if err == nil || attempts != 2 {
+		t.Fatalf("expected the update to fail on its second attempt. got : %v after %d attempts", err, attempts)
+	}
+}
+
+func TestRetryTransportHonoursRetryAfter(t *testing.T) {
+	server, _, _ := flakyServer(t, []int{429}, http.Header{"Retry-After": []string{"7"}})
+	transport, waits := testRetryTransport(3, 30*time.Second)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	res, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	if len(*waits) != 1 || (*waits)[0] != 7*time.Second {
+		t.Fatalf("bad waits. expected: %v. got : %v", []time.Duration{7 * time.Second}, *waits)
+	}
+}
+
+func TestRetryTransportBacksOff(t *testing.T) {
+	server, _, _ := flakyServer(t, []int{503, 503, 503, 503}, nil)
+	transport, waits := testRetryTransport(4, 3*time.Second)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	res, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	// 500ms, 1s, 2s, then capped at 3s, each jittered down by at most half.
+	maximums := []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second, 3 * time.Second}
+
+	if len(*waits) != len(maximums) {
+		t.Fatalf("bad wait count. expected: %v. got : %v", len(maximums), len(*waits))
+	}
+
+	for i, wait := range *waits {
+		if wait < maximums[i]/2 || wait > maximums[i] {
+			t.Fatalf("bad wait %d. expected between %v and %v. got : %v", i, maximums[i]/2, maximums[i], wait)
+		}
+	}
+}
+
+func TestRetryTransportReplaysBody(t *testing.T) {
+	server, _, bodies := flakyServer(t, []int{429}, nil)
+	transport, _ := testRetryTransport(3, 30*time.Second)
+
+	// A reader without a GetBody, as a client might send.
+	req, _ := http.NewRequest(http.MethodPatch, server.URL, io.NopCloser(strings.NewReader(`{"name":"hello"}`)))
+	res, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	if len(*bodies) != 2 || (*bodies)[0] != `{"name":"hello"}` || (*bodies)[1] != `{"name":"hello"}` {
+		t.Fatalf("bad bodies: %v", *bodies)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if wait, ok := parseRetryAfter("3"); !ok || wait != 3*time.Second {
+		t.Fatalf("bad wait for seconds: %v, %v", wait, ok)
+	}
+
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(date); !ok || wait <= 0 || wait > 10*time.Second {
+		t.Fatalf("bad wait for date: %v, %v", wait, ok)
+	}
+
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Fatalf("expected an unparseable header to be ignored")
+	}
+}
+
+func TestTimeoutTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	transport := &timeoutTransport{next: http.DefaultTransport, timeout: 50 * time.Millisecond}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err := (&http.Client{Transport: transport}).Do(req)
+	if err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+		t.Fatalf("expected a timeout. got : %v", err)
+	}
+}
+
+func TestBaseTransportTrustsCaCertPem(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	caCertPem := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	get := func(transport http.RoundTripper) error {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		res, err := (&http.Client{Transport: transport}).Do(req)
+		if err == nil {
+			res.Body.Close()
+		}
+		return err
+	}
+
+	if err := get(newBaseTransport(nil, nil, false)); err == nil {
+		t.Fatalf("expected the test server's certificate to be untrusted")
+	}
+
+	rootCAs, err := loadRootCAs("", caCertPem)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := get(newBaseTransport(nil, rootCAs, false)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := get(newBaseTransport(nil, nil, true)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := loadRootCAs("", "not a certificate"); err == nil {
+		t.Fatalf("expected an error for a PEM without certificates")
+	}
+}
+
+func TestBaseTransportUsesProxy(t *testing.T) {
+	var proxied int32
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+		if r.URL.Host != "api.authsignal.test" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(proxy.Close)
+
+	proxyUrl, _ := url.Parse(proxy.URL)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://api.authsignal.test/v1/management/actions", nil)
+	res, err := (&http.Client{Transport: newBaseTransport(proxyUrl, nil, false)}).Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK || proxied != 1 {
+		t.Fatalf("expected the request to go through the proxy. status: %v, proxied: %v", res.StatusCode, proxied)
+	}
+}
+
+func TestConfigureTransport(t *testing.T) {
+	config := func() authsignalProviderModel {
+		return authsignalProviderModel{
+			MaxRetries:         types.Int64Null(),
+			RetryMaxWait:       types.StringNull(),
+			HttpProxy:          types.StringNull(),
+			CaCertFile:         types.StringNull(),
+			CaCertPem:          types.StringNull(),
+			InsecureSkipVerify: types.BoolNull(),
+			RequestTimeout:     types.StringNull(),
+		}
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		transport, diags := configureTransport(context.Background(), config())
+		if diags.HasError() || diags.WarningsCount() != 0 {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		retry := transport.(*cacheTransport).next.(*retryTransport)
+		if retry.maxRetries != defaultMaxRetries || retry.maxWait != defaultRetryMaxWait {
+			t.Fatalf("bad retry settings: %v, %v", retry.maxRetries, retry.maxWait)
+		}
+
+		logging := retry.next.(*loggingTransport)
+		if _, ok := logging.next.(*http.Transport); !ok {
+			t.Fatalf("expected no timeout transport. got : %T", logging.next)
+		}
+	})
+
+	t.Run("environment variables", func(t *testing.T) {
+		t.Setenv("AUTHSIGNAL_REQUEST_TIMEOUT", "10s")
+		t.Setenv("AUTHSIGNAL_HTTP_PROXY", "http://proxy.example.com:3128")
+		t.Setenv("AUTHSIGNAL_INSECURE_SKIP_VERIFY", "true")
+
+		transport, diags := configureTransport(context.Background(), config())
+		if diags.HasError() || diags.WarningsCount() != 1 {
+			t.Fatalf("expected a single warning. got : %v", diags)
+		}
+
+		logging := transport.(*cacheTransport).next.(*retryTransport).next.(*loggingTransport)
+		timeout, ok := logging.next.(*timeoutTransport)
+		if !ok || timeout.timeout != 10*time.Second {
+			t.Fatalf("expected a 10s timeout transport. got : %#v", logging.next)
+		}
+
+		base := timeout.next.(*http.Transport)
+		if !base.TLSClientConfig.InsecureSkipVerify {
+			t.Fatalf("expected TLS verification to be skipped")
+		}
+
+		proxyUrl, _ := base.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "api.authsignal.com"}})
+		if proxyUrl == nil || proxyUrl.Host != "proxy.example.com:3128" {
+			t.Fatalf("bad proxy: %v", proxyUrl)
+		}
+	})
+
+	t.Run("configuration overrides environment variables", func(t *testing.T) {
+		t.Setenv("AUTHSIGNAL_REQUEST_TIMEOUT", "10s")
+
+		c := config()
+		c.RequestTimeout = types.StringValue("1m")
+
+		transport, diags := configureTransport(context.Background(), c)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		if timeout := transport.(*cacheTransport).next.(*retryTransport).next.(*loggingTransport).next.(*timeoutTransport); timeout.timeout != time.Minute {
+			t.Fatalf("bad timeout: %v", timeout.timeout)
+		}
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		t.Setenv("AUTHSIGNAL_INSECURE_SKIP_VERIFY", "sometimes")
+
+		c := config()
+		c.HttpProxy = types.StringValue("proxy.example.com")
+		c.CaCertFile = types.StringValue(filepath.Join(t.TempDir(), "missing.pem"))
+
+		_, diags := configureTransport(context.Background(), c)
+		if diags.ErrorsCount() != 3 {
+			t.Fatalf("expected 3 errors. got : %v", diags)
+		}
+	})
+}
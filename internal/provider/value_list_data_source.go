@@ -23,7 +23,7 @@ func NewValueListDataSource() datasource.DataSource {
 }
 
 type valueListDataSource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 type valueListDataSourceModel struct {
@@ -33,6 +33,7 @@ type valueListDataSourceModel struct {
 	IsActive              types.Bool   `tfsdk:"is_active"`
 	ValueListItemsStrings types.List   `tfsdk:"value_list_items_strings"`
 	ValueListItemsNumbers types.List   `tfsdk:"value_list_items_numbers"`
+	Tenant                types.String `tfsdk:"tenant"`
 }
 
 func (d *valueListDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -42,6 +43,7 @@ func (d *valueListDataSource) Metadata(_ context.Context, req datasource.Metadat
 func (d *valueListDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantDataSourceAttribute(),
 			"name": schema.StringAttribute{
 				Description: "The name of the list.",
 				Computed:    true,
@@ -100,13 +102,20 @@ func (d *valueListDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	valueList, _, err := d.client.GetValueList(data.Alias.ValueString())
+	client, diags := d.client.forTenant(data.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	valueList, _, err := client.GetValueList(ctx, data.Alias.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to Read Authsignal ValueList", err.Error())
 		return
 	}
 
 	valueListState := valueListDataSourceModel{
+		Tenant:   data.Tenant,
 		Name:     types.StringValue(valueList.Name),
 		Alias:    types.StringValue(valueList.Alias),
 		ItemType: types.StringValue(valueList.ItemType),
@@ -134,12 +143,12 @@ func (d *valueListDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
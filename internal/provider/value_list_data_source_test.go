@@ -13,7 +13,7 @@ func TestAccValueListDataSource(t *testing.T) {
 			{
 				Config: `data "authsignal_value_list" "hello_world_strings" {alias="hello-world-strings"}`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					// these fields correspond to an Value List that already exists in dev.
+					// these fields correspond to an Value List that already exists in dev, and in the fake Management API.
 					resource.TestCheckResourceAttr("data.authsignal_value_list.hello_world_strings", "alias", "hello-world-strings"),
 					resource.TestCheckResourceAttr("data.authsignal_value_list.hello_world_strings", "item_type", "string"),
 					resource.TestCheckResourceAttr("data.authsignal_value_list.hello_world_strings", "name", "Hello World Strings"),
@@ -26,7 +26,7 @@ func TestAccValueListDataSource(t *testing.T) {
 			{
 				Config: `data "authsignal_value_list" "hello_world_numbers" {alias="hello-world-numbers"}`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					// these fields correspond to an Value List that already exists in dev.
+					// these fields correspond to an Value List that already exists in dev, and in the fake Management API.
 					resource.TestCheckResourceAttr("data.authsignal_value_list.hello_world_numbers", "alias", "hello-world-numbers"),
 					resource.TestCheckResourceAttr("data.authsignal_value_list.hello_world_numbers", "item_type", "number"),
 					resource.TestCheckResourceAttr("data.authsignal_value_list.hello_world_numbers", "name", "Hello World Numbers"),
@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/authsignal/authsignal-management-go/v6"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &valueListItemResource{}
+	_ resource.ResourceWithConfigure   = &valueListItemResource{}
+	_ resource.ResourceWithImportState = &valueListItemResource{}
+	_ resource.ResourceWithModifyPlan  = &valueListItemResource{}
+)
+
+func NewValueListItemResource() resource.Resource {
+	return &valueListItemResource{}
+}
+
+// valueListItemResource is a single item of a value list, so that different workspaces can each add
+// their own items to a shared list. The list itself is managed with `authsignal_value_list` and
+// `items_managed_externally`, or outside Terraform.
+type valueListItemResource struct {
+	client *authsignalClient
+}
+
+type valueListItemResourceModel struct {
+	Alias  types.String `tfsdk:"alias"`
+	Value  types.String `tfsdk:"value"`
+	Tenant types.String `tfsdk:"tenant"`
+}
+
+func (r *valueListItemResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_value_list_item"
+}
+
+func (r *valueListItemResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"tenant": tenantResourceAttribute(),
+			"alias": schema.StringAttribute{
+				Description: "The alias of the value list to add the item to.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "The item, such as an email address or an IP address. The items of a number value list are given as strings, such as `\"42\"`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// valueListItem converts value to an item of a value list of itemType.
+func valueListItem(itemType string, value string) (authsignal.ValueListItem, error) {
+	if itemType != "number" {
+		return value, nil
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("the value list holds numbers, and %q is not a number", value)
+	}
+
+	return number, nil
+}
+
+// ModifyPlan checks that the value is an item of the value list's type, such as a number for a number
+// list, when the value list already exists. One created in the same apply is checked on create.
+func (r *valueListItemResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan valueListItemResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Alias.IsUnknown() || plan.Value.IsUnknown() || plan.Tenant.IsUnknown() {
+		return
+	}
+
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A value list that can't be read, such as one that doesn't exist yet, is left for Create to report.
+	valueList, _, err := client.GetValueList(ctx, plan.Alias.ValueString())
+	if err != nil {
+		return
+	}
+
+	if _, err := valueListItem(valueList.ItemType, plan.Value.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid value list item", err.Error())
+	}
+}
+
+func (r *valueListItemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan valueListItemResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The value is an item of the value list's type, which is known once the value list is read.
+	var itemErr error
+	_, statusCode, err := client.ChangeValueListItems(ctx, plan.Alias.ValueString(), func(valueList *authsignal.ValueListResponse) ([]authsignal.ValueListItem, []authsignal.ValueListItem) {
+		var item authsignal.ValueListItem
+		if item, itemErr = valueListItem(valueList.ItemType, plan.Value.ValueString()); itemErr != nil {
+			return nil, nil
+		}
+		return []authsignal.ValueListItem{item}, nil
+	})
+	if itemErr != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid value list item", itemErr.Error())
+		return
+	}
+
+	if statusCode == 404 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("alias"),
+			"Unable to Read Authsignal ValueList",
+			err.Error(),
+		)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(
+			"Error creating value list item",
+			"Could not add item to value list",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *valueListItemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state valueListItemResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.forTenant(state.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	valueList, statusCode, err := client.GetValueList(ctx, state.Alias.ValueString())
+
+	if statusCode == 404 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Authsignal ValueList",
+			err.Error(),
+		)
+		return
+	}
+
+	item, err := valueListItem(valueList.ItemType, state.Value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("value"),
+			"Invalid value list item",
+			err.Error()+". If the value list's item type has changed, remove this item from the state with `terraform state rm`.",
+		)
+		return
+	}
+
+	if len(ownedValueListItems(valueList.ValueListItems, []authsignal.ValueListItem{item})) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *valueListItemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every attribute replaces the item, so there is nothing to send.
+	var plan valueListItemResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *valueListItemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state valueListItemResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.forTenant(state.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var itemErr error
+	_, statusCode, err := client.ChangeValueListItems(ctx, state.Alias.ValueString(), func(valueList *authsignal.ValueListResponse) ([]authsignal.ValueListItem, []authsignal.ValueListItem) {
+		var item authsignal.ValueListItem
+		if item, itemErr = valueListItem(valueList.ItemType, state.Value.ValueString()); itemErr != nil {
+			return nil, nil
+		}
+		return nil, []authsignal.ValueListItem{item}
+	})
+	if itemErr != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid value list item", itemErr.Error())
+		return
+	}
+
+	// The value list is gone, and the item with it.
+	if statusCode == 404 {
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(
+			"Error Deleting Authsignal value list item",
+			"Could not remove item from value list",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
+		return
+	}
+}
+
+func (r *valueListItemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Aliases never hold a slash, but values such as CIDR ranges can, so only the first one separates them.
+	alias, value, ok := strings.Cut(r.client.importTenant(ctx, req, resp), "/")
+
+	if !ok || alias == "" || value == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: alias/value or tenant:alias/value. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("alias"), alias)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("value"), value)...)
+}
+
+func (r *valueListItemResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*authsignalClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
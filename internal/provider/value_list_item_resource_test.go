@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccValueListItemResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing alongside a value list that ignores the items it doesn't own
+			{
+				Config: `
+					resource "authsignal_value_list" "terraform-acc-test-shared" {
+						name = "Terraform Acc Test Shared"
+						is_active = true
+						items_managed_externally = true
+						value_list_items_strings = ["fraud@example.com"]
+					}
+
+					resource "authsignal_value_list_item" "terraform-acc-test-shared-ip" {
+						alias = authsignal_value_list.terraform-acc-test-shared.alias
+						value = "10.0.0.0/8"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_value_list_item.terraform-acc-test-shared-ip", "alias", "terraform-acc-test-shared"),
+					resource.TestCheckResourceAttr("authsignal_value_list_item.terraform-acc-test-shared-ip", "value", "10.0.0.0/8"),
+					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-shared", "value_list_items_strings.#", "1"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-shared", "value_list_items_strings.*", "fraud@example.com"),
+				),
+			},
+			// The value list plans no changes for the item it doesn't own
+			{
+				Config: `
+					resource "authsignal_value_list" "terraform-acc-test-shared" {
+						name = "Terraform Acc Test Shared"
+						is_active = true
+						items_managed_externally = true
+						value_list_items_strings = ["fraud@example.com"]
+					}
+
+					resource "authsignal_value_list_item" "terraform-acc-test-shared-ip" {
+						alias = authsignal_value_list.terraform-acc-test-shared.alias
+						value = "10.0.0.0/8"
+					}
+				`,
+				PlanOnly: true,
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "authsignal_value_list_item.terraform-acc-test-shared-ip",
+				ImportState:                          true,
+				ImportStateId:                        "terraform-acc-test-shared/10.0.0.0/8",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "value",
+			},
+			// Update testing replaces the item while the value list keeps its own
+			{
+				Config: `
+					resource "authsignal_value_list" "terraform-acc-test-shared" {
+						name = "Terraform Acc Test Shared"
+						is_active = true
+						items_managed_externally = true
+						value_list_items_strings = ["fraud@example.com", "chargeback@example.com"]
+					}
+
+					resource "authsignal_value_list_item" "terraform-acc-test-shared-ip" {
+						alias = authsignal_value_list.terraform-acc-test-shared.alias
+						value = "192.168.0.0/16"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_value_list_item.terraform-acc-test-shared-ip", "value", "192.168.0.0/16"),
+					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-shared", "value_list_items_strings.#", "2"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-shared", "value_list_items_strings.*", "fraud@example.com"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-shared", "value_list_items_strings.*", "chargeback@example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccValueListItemResourceNumber(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing for a number value list whose items are all managed separately
+			{
+				Config: `
+					resource "authsignal_value_list" "terraform-acc-test-shared-numbers" {
+						name = "Terraform Acc Test Shared Numbers"
+						is_active = true
+						item_type = "number"
+						items_managed_externally = true
+					}
+
+					resource "authsignal_value_list_item" "terraform-acc-test-shared-number" {
+						alias = authsignal_value_list.terraform-acc-test-shared-numbers.alias
+						value = "42"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_value_list_item.terraform-acc-test-shared-number", "value", "42"),
+					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-shared-numbers", "item_type", "number"),
+					resource.TestCheckNoResourceAttr("authsignal_value_list.terraform-acc-test-shared-numbers", "value_list_items_numbers"),
+				),
+			},
+			// A value that isn't a number fails the plan rather than the apply
+			{
+				Config: `
+					resource "authsignal_value_list" "terraform-acc-test-shared-numbers" {
+						name = "Terraform Acc Test Shared Numbers"
+						is_active = true
+						item_type = "number"
+						items_managed_externally = true
+					}
+
+					resource "authsignal_value_list_item" "terraform-acc-test-shared-number" {
+						alias = authsignal_value_list.terraform-acc-test-shared-numbers.alias
+						value = "forty-two"
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"forty-two" is not a number`),
+			},
+			// Importing a value that isn't a number fails rather than finding no item
+			{
+				ResourceName:  "authsignal_value_list_item.terraform-acc-test-shared-number",
+				ImportState:   true,
+				ImportStateId: "terraform-acc-test-shared-numbers/forty-two",
+				ExpectError:   regexp.MustCompile(`"forty-two" is not a number`),
+			},
+		},
+	})
+}
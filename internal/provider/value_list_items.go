@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/authsignal/authsignal-management-go/v6"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// A value list's items are a set, as the API does not keep them in order, so reordering them in the
+// configuration plans no change.
+
+// valueListItemKey identifies an item, so that the number 5 from the configuration and the 5.0 the
+// API returns are the same item.
+func valueListItemKey(item authsignal.ValueListItem) string {
+	key, err := json.Marshal(item)
+	if err != nil {
+		return ""
+	}
+	return string(key)
+}
+
+// valueListItemsFromSets returns the items in whichever of the sets is used, in a stable order. It
+// returns false when the items are not known yet.
+func valueListItemsFromSets(ctx context.Context, stringItems types.Set, numberItems types.Set) ([]authsignal.ValueListItem, bool, diag.Diagnostics) {
+	for _, set := range []types.Set{stringItems, numberItems} {
+		if set.IsUnknown() {
+			return nil, false, nil
+		}
+		for _, element := range set.Elements() {
+			if element.IsUnknown() {
+				return nil, false, nil
+			}
+		}
+	}
+
+	var items []authsignal.ValueListItem
+
+	if !numberItems.IsNull() {
+		var values []float64
+		diags := numberItems.ElementsAs(ctx, &values, false)
+		if diags.HasError() {
+			return nil, false, diags
+		}
+		for _, value := range values {
+			items = append(items, value)
+		}
+	}
+
+	if !stringItems.IsNull() {
+		var values []string
+		diags := stringItems.ElementsAs(ctx, &values, false)
+		if diags.HasError() {
+			return nil, false, diags
+		}
+		for _, value := range values {
+			items = append(items, value)
+		}
+	}
+
+	sort.SliceStable(items, func(a, b int) bool {
+		return valueListItemKey(items[a]) < valueListItemKey(items[b])
+	})
+
+	return items, true, nil
+}
+
+// diffValueListItems returns the items in planned that are not in current, and those in current
+// that are not in planned.
+func diffValueListItems(current []authsignal.ValueListItem, planned []authsignal.ValueListItem) (added []authsignal.ValueListItem, removed []authsignal.ValueListItem) {
+	currentKeys := map[string]bool{}
+	for _, item := range current {
+		currentKeys[valueListItemKey(item)] = true
+	}
+
+	plannedKeys := map[string]bool{}
+	for _, item := range planned {
+		key := valueListItemKey(item)
+		if !currentKeys[key] && !plannedKeys[key] {
+			added = append(added, item)
+		}
+		plannedKeys[key] = true
+	}
+
+	removedKeys := map[string]bool{}
+	for _, item := range current {
+		key := valueListItemKey(item)
+		if !plannedKeys[key] && !removedKeys[key] {
+			removed = append(removed, item)
+			removedKeys[key] = true
+		}
+	}
+
+	return added, removed
+}
+
+// ownedValueListItems returns the items in the value list that are also in owned, for a value list
+// whose other items are managed elsewhere.
+func ownedValueListItems(items []authsignal.ValueListItem, owned []authsignal.ValueListItem) []authsignal.ValueListItem {
+	ownedKeys := map[string]bool{}
+	for _, item := range owned {
+		ownedKeys[valueListItemKey(item)] = true
+	}
+
+	kept := []authsignal.ValueListItem{}
+	for _, item := range items {
+		if ownedKeys[valueListItemKey(item)] {
+			kept = append(kept, item)
+		}
+	}
+
+	return kept
+}
+
+// valueListItemSets converts the items the API returns into the resource's sets. Duplicates the API
+// may hold are dropped, and an empty list is null.
+func valueListItemSets(ctx context.Context, itemType string, items []authsignal.ValueListItem) (types.Set, types.Set, diag.Diagnostics) {
+	stringsSet := types.SetNull(types.StringType)
+	numbersSet := types.SetNull(types.Float64Type)
+
+	if len(items) == 0 {
+		return stringsSet, numbersSet, nil
+	}
+
+	elements := []attr.Value{}
+	seen := map[string]bool{}
+	for _, item := range items {
+		key := valueListItemKey(item)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		switch value := item.(type) {
+		case string:
+			elements = append(elements, types.StringValue(value))
+		case float64:
+			elements = append(elements, types.Float64Value(value))
+		case int:
+			elements = append(elements, types.Float64Value(float64(value)))
+		case int64:
+			elements = append(elements, types.Float64Value(float64(value)))
+		case json.Number:
+			number, _ := value.Float64()
+			elements = append(elements, types.Float64Value(number))
+		}
+	}
+
+	if itemType == "number" {
+		set, diags := types.SetValue(types.Float64Type, elements)
+		return stringsSet, set, diags
+	}
+
+	set, diags := types.SetValue(types.StringType, elements)
+	return set, numbersSet, diags
+}
+
+// valueListItemTypeFromItems plans `item_type` from the planned items when it is not configured,
+// keeping the stored type for a value list without any, so that replacing a string list with a number
+// list plans the new type.
+type valueListItemTypeFromItems struct{}
+
+func (m valueListItemTypeFromItems) Description(_ context.Context) string {
+	return "Takes the value list's item type from the planned value list items when it is not set."
+}
+
+func (m valueListItemTypeFromItems) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m valueListItemTypeFromItems) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.Plan.Raw.IsNull() || !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var stringItems, numberItems types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("value_list_items_strings"), &stringItems)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("value_list_items_numbers"), &numberItems)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case stringItems.IsUnknown() || numberItems.IsUnknown():
+		resp.PlanValue = types.StringUnknown()
+	case len(numberItems.Elements()) > 0:
+		resp.PlanValue = types.StringValue("number")
+	case len(stringItems.Elements()) > 0:
+		resp.PlanValue = types.StringValue("string")
+	case !req.StateValue.IsNull():
+		resp.PlanValue = req.StateValue
+	default:
+		resp.PlanValue = types.StringValue("string")
+	}
+}
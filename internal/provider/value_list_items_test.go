@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/authsignal/authsignal-management-go/v6"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDiffValueListItems(t *testing.T) {
+	current := []authsignal.ValueListItem{"a", "b", "c", "c"}
+	planned := []authsignal.ValueListItem{"c", "d", "a", "d"}
+
+	added, removed := diffValueListItems(current, planned)
+
+	if fmt.Sprint(added) != "[d]" {
+		t.Fatalf("bad added items. expected: [d]. got : %v", added)
+	}
+	if fmt.Sprint(removed) != "[b]" {
+		t.Fatalf("bad removed items. expected: [b]. got : %v", removed)
+	}
+
+	added, removed = diffValueListItems(
+		[]authsignal.ValueListItem{float64(1), float64(2)},
+		[]authsignal.ValueListItem{float64(2), float64(1)},
+	)
+	if len(added) != 0 || len(removed) != 0 {
+		t.Fatalf("expected no changes for reordered items, got: %v %v", added, removed)
+	}
+}
+
+func TestValueListItemsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	stringItems, numberItems, diags := valueListItemSets(ctx, "number", []authsignal.ValueListItem{float64(3), float64(1), float64(3)})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !stringItems.IsNull() || len(numberItems.Elements()) != 2 {
+		t.Fatalf("bad sets: %s %s", stringItems, numberItems)
+	}
+
+	items, known, diags := valueListItemsFromSets(ctx, stringItems, numberItems)
+	if diags.HasError() || !known {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if fmt.Sprint(items) != "[1 3]" {
+		t.Fatalf("bad items. expected: [1 3]. got : %v", items)
+	}
+
+	unknown := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringUnknown()})
+	if _, known, _ := valueListItemsFromSets(ctx, unknown, types.SetNull(types.Float64Type)); known {
+		t.Fatal("expected items with an unknown element not to be known")
+	}
+}
+
+func TestValueListUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &valueListResource{}
+
+	upgrader := r.UpgradeState(ctx)[0]
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	priorState := tfsdk.State{Schema: *upgrader.PriorSchema}
+	diags := priorState.Set(ctx, valueListResourceModelV0{
+		Name:     types.StringValue("Blocked Emails"),
+		Alias:    types.StringValue("blocked-emails"),
+		ItemType: types.StringValue("string"),
+		IsActive: types.BoolValue(true),
+		ValueListItemsStrings: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("b@example.com"),
+			types.StringValue("a@example.com"),
+			types.StringValue("b@example.com"),
+		}),
+		ValueListItemsNumbers: types.ListNull(types.Float64Type),
+		Tenant:                types.StringNull(),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &priorState}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var upgraded valueListResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &upgraded)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if upgraded.Alias.ValueString() != "blocked-emails" || !upgraded.ValueListItemsNumbers.IsNull() {
+		t.Fatalf("attributes were not carried over: %+v", upgraded)
+	}
+
+	if len(upgraded.ValueListItemsStrings.Elements()) != 2 {
+		t.Fatalf("expected the repeated item to be dropped, got: %s", upgraded.ValueListItemsStrings)
+	}
+}
+
+func TestOwnedValueListItems(t *testing.T) {
+	item, err := valueListItem("number", "42")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	owned := ownedValueListItems([]authsignal.ValueListItem{float64(7), float64(42), "42"}, []authsignal.ValueListItem{item})
+	if fmt.Sprint(owned) != "[42]" || valueListItemKey(owned[0]) != "42" {
+		t.Fatalf("bad owned items. expected: [42]. got : %v", owned)
+	}
+
+	if _, err := valueListItem("number", "not-a-number"); err == nil {
+		t.Fatal("expected an error for an item of a number value list that is not a number")
+	}
+
+	if owned := ownedValueListItems([]authsignal.ValueListItem{"a"}, nil); owned == nil || len(owned) != 0 {
+		t.Fatalf("expected no owned items, got: %v", owned)
+	}
+}
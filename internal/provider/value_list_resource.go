@@ -5,20 +5,22 @@ import (
 	"fmt"
 
 	"github.com/authsignal/authsignal-management-go/v6"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.Resource                = &valueListResource{}
-	_ resource.ResourceWithConfigure   = &valueListResource{}
-	_ resource.ResourceWithImportState = &valueListResource{}
+	_ resource.Resource                 = &valueListResource{}
+	_ resource.ResourceWithConfigure    = &valueListResource{}
+	_ resource.ResourceWithImportState  = &valueListResource{}
+	_ resource.ResourceWithUpgradeState = &valueListResource{}
 )
 
 func NewValueListResource() resource.Resource {
@@ -26,23 +28,36 @@ func NewValueListResource() resource.Resource {
 }
 
 type valueListResource struct {
-	client *authsignal.Client
+	client *authsignalClient
 }
 
 type valueListResourceModel struct {
+	Name                   types.String `tfsdk:"name"`
+	Alias                  types.String `tfsdk:"alias"`
+	ItemType               types.String `tfsdk:"item_type"`
+	IsActive               types.Bool   `tfsdk:"is_active"`
+	ValueListItemsStrings  types.Set    `tfsdk:"value_list_items_strings"`
+	ValueListItemsNumbers  types.Set    `tfsdk:"value_list_items_numbers"`
+	ItemsManagedExternally types.Bool   `tfsdk:"items_managed_externally"`
+	Tenant                 types.String `tfsdk:"tenant"`
+}
+
+// valueListResourceModelV0 is the state before the items were sets.
+type valueListResourceModelV0 struct {
 	Name                  types.String `tfsdk:"name"`
 	Alias                 types.String `tfsdk:"alias"`
 	ItemType              types.String `tfsdk:"item_type"`
 	IsActive              types.Bool   `tfsdk:"is_active"`
 	ValueListItemsStrings types.List   `tfsdk:"value_list_items_strings"`
 	ValueListItemsNumbers types.List   `tfsdk:"value_list_items_numbers"`
+	Tenant                types.String `tfsdk:"tenant"`
 }
 
 func (r *valueListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_value_list"
 }
 
-func checkListTypeHasntChanged(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+func checkListTypeHasntChanged(ctx context.Context, req planmodifier.SetRequest, resp *setplanmodifier.RequiresReplaceIfFuncResponse) {
 	var plan valueListResourceModel
 	_ = req.Plan.Get(ctx, &plan)
 	var state valueListResourceModel
@@ -57,7 +72,9 @@ func checkListTypeHasntChanged(ctx context.Context, req planmodifier.ListRequest
 
 func (r *valueListResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
+			"tenant": tenantResourceAttribute(),
 			"name": schema.StringAttribute{
 				Description: "The name of the value list.",
 				Required:    true,
@@ -73,82 +90,163 @@ func (r *valueListResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"item_type": schema.StringAttribute{
-				Description: "The type of items in the value list (string or number).",
+				Description: "The type of items in the value list (string or number). Taken from the items when not set, which makes it `string` for a list without any; set it for a number list whose items are all added with `authsignal_value_list_item`. Changing it replaces the value list.",
+				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("string", "number"),
+				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					valueListItemTypeFromItems{},
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
+			"items_managed_externally": schema.BoolAttribute{
+				Description: "Set this to `true` when other workspaces or `authsignal_value_list_item` resources also add items to the value list. The value list then only adds and removes the items in its own `value_list_items_strings` or `value_list_items_numbers`, and ignores the rest. Turning it on removes no items. As the Management API only replaces a value list's items as a whole, each change reads the list and writes it back. When the Management API sends an ETag with the value list, the write is conditional on it and retried if the list changed in between; otherwise an item added elsewhere at the same moment can be lost. Defaults to `false`, where any item not in the configuration is removed.",
+				Optional:    true,
+			},
 			"is_active": schema.BoolAttribute{
 				Description: "Whether or not the list is active. This currently has no effect, please set the value to `true`.",
 				Required:    true,
 			},
-			"value_list_items_strings": schema.ListAttribute{
+			"value_list_items_strings": schema.SetAttribute{
 				ElementType: types.StringType,
-				Description: "A list of string items in the value list.",
+				Description: "The string items in the value list. Order does not matter. The whole set is sent whenever an item changes, as the Management API has no calls to add or remove single items.",
 				Optional:    true,
-				PlanModifiers: []planmodifier.List{
-					listplanmodifier.RequiresReplaceIf(checkListTypeHasntChanged, "Ensures the list type hasn't changed.", "Ensures the list type hasn't changed."),
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplaceIf(checkListTypeHasntChanged, "Ensures the list type hasn't changed.", "Ensures the list type hasn't changed."),
 				},
 			},
-			"value_list_items_numbers": schema.ListAttribute{
+			"value_list_items_numbers": schema.SetAttribute{
 				ElementType: types.Float64Type,
-				Description: "A list of number items in the value list.",
+				Description: "The number items in the value list. Order does not matter. The whole set is sent whenever an item changes, as the Management API has no calls to add or remove single items.",
 				Optional:    true,
-				PlanModifiers: []planmodifier.List{
-					listplanmodifier.RequiresReplaceIf(checkListTypeHasntChanged, "Ensures the list type hasn't changed.", "Ensures the list type hasn't changed."),
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplaceIf(checkListTypeHasntChanged, "Ensures the list type hasn't changed.", "Ensures the list type hasn't changed."),
 				},
 			},
 		},
 	}
 }
 
-func prepareValueListItems(ctx context.Context, plan valueListResourceModel, itemType string, setNullIfEmpty bool) (authsignal.NullableJsonInput[[]authsignal.ValueListItem], diag.Diagnostics) {
-	var itemList []authsignal.ValueListItem
-
-	if itemType == "number" {
-		valueListItemsNumbersSlice := make([]float64, 0, len(plan.ValueListItemsNumbers.Elements()))
-		diags := plan.ValueListItemsNumbers.ElementsAs(ctx, &valueListItemsNumbersSlice, false)
-		if diags.HasError() {
-			return nil, diags
-		}
-
-		for _, item := range valueListItemsNumbersSlice {
-			itemList = append(itemList, item)
-		}
-	} else {
-		valueListItemsStringsSlice := make([]string, 0, len(plan.ValueListItemsStrings.Elements()))
-		diags := plan.ValueListItemsStrings.ElementsAs(ctx, &valueListItemsStringsSlice, false)
-		if diags.HasError() {
-			return nil, diags
-		}
-
-		for _, item := range valueListItemsStringsSlice {
-			itemList = append(itemList, item)
-		}
-	}
-
-	if len(itemList) > 0 {
-		return authsignal.SetValue(itemList), nil
-	} else if setNullIfEmpty {
-		return authsignal.SetNull(itemList), nil
+func (r *valueListResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 kept the items as lists, which may repeat an item.
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"tenant": schema.StringAttribute{
+						Optional: true,
+					},
+					"name": schema.StringAttribute{
+						Required: true,
+					},
+					"alias": schema.StringAttribute{
+						Computed: true,
+					},
+					"item_type": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+					},
+					"is_active": schema.BoolAttribute{
+						Required: true,
+					},
+					"value_list_items_strings": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"value_list_items_numbers": schema.ListAttribute{
+						ElementType: types.Float64Type,
+						Optional:    true,
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior valueListResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				var items []authsignal.ValueListItem
+				for _, list := range []types.List{prior.ValueListItemsStrings, prior.ValueListItemsNumbers} {
+					for _, element := range list.Elements() {
+						switch element := element.(type) {
+						case types.String:
+							items = append(items, element.ValueString())
+						case types.Float64:
+							items = append(items, element.ValueFloat64())
+						}
+					}
+				}
+
+				itemType := "string"
+				if !prior.ValueListItemsNumbers.IsNull() {
+					itemType = "number"
+				}
+
+				stringItems, numberItems, diags := valueListItemSets(ctx, itemType, items)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				// An empty list stays an empty set rather than becoming null, so the configuration still matches.
+				if len(items) == 0 {
+					if !prior.ValueListItemsStrings.IsNull() {
+						stringItems = types.SetValueMust(types.StringType, nil)
+					}
+					if !prior.ValueListItemsNumbers.IsNull() {
+						numberItems = types.SetValueMust(types.Float64Type, nil)
+					}
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, valueListResourceModel{
+					Name:                   prior.Name,
+					Alias:                  prior.Alias,
+					ItemType:               prior.ItemType,
+					IsActive:               prior.IsActive,
+					ValueListItemsStrings:  stringItems,
+					ValueListItemsNumbers:  numberItems,
+					ItemsManagedExternally: types.BoolNull(),
+					Tenant:                 prior.Tenant,
+				})...)
+			},
+		},
 	}
-	return nil, nil
 }
 
 func getListType(plan valueListResourceModel) string {
-	if len(plan.ValueListItemsNumbers.Elements()) > 0 && len(plan.ValueListItemsStrings.Elements()) > 0 {
-		return "error"
-	}
+	hasNumbers := len(plan.ValueListItemsNumbers.Elements()) > 0
+	hasStrings := len(plan.ValueListItemsStrings.Elements()) > 0
+	itemType := plan.ItemType.ValueString()
 
-	if len(plan.ValueListItemsNumbers.Elements()) > 0 {
+	switch {
+	case hasNumbers && hasStrings:
+		return "error"
+	case hasNumbers:
+		if itemType == "string" {
+			return "error"
+		}
 		return "number"
+	case hasStrings:
+		if itemType == "number" {
+			return "error"
+		}
+		return "string"
+	case itemType != "":
+		return itemType
 	}
 
 	return "string"
 }
 
 func (r *valueListResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan valueListResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -165,27 +263,37 @@ func (r *valueListResource) Create(ctx context.Context, req resource.CreateReque
 	if itemType == "error" {
 		resp.Diagnostics.AddError(
 			"Invalid value list items",
-			"Only one of \"value_list_items_strings\" or \"value_list_items_numbers\" can be set",
+			"Only one of \"value_list_items_strings\" or \"value_list_items_numbers\" can be set, and it must match \"item_type\" when that is set",
 		)
 		return
 	}
 
 	valueListToCreate.ItemType = authsignal.SetValue(itemType)
 
-	valueListItems, diags := prepareValueListItems(ctx, plan, itemType, false)
+	items, _, diags := valueListItemsFromSets(ctx, plan.ValueListItemsStrings, plan.ValueListItemsNumbers)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	valueListToCreate.ValueListItems = valueListItems
+	if len(items) > 0 {
+		valueListToCreate.ValueListItems = authsignal.SetValue(items)
+	}
+
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	valueList, _, err := r.client.CreateValueList(valueListToCreate)
+	valueList, _, err := client.CreateValueList(ctx, valueListToCreate)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error creating value list",
-			"Could not create value list, unexpected error: "+err.Error(),
-		)
+			"Could not create value list",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 
@@ -208,7 +316,13 @@ func (r *valueListResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	valueList, statusCode, err := r.client.GetValueList(state.Alias.ValueString())
+	client, diags := r.client.forTenant(state.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	valueList, statusCode, err := client.GetValueList(ctx, state.Alias.ValueString())
 
 	if statusCode == 404 {
 		resp.State.RemoveResource(ctx)
@@ -223,8 +337,18 @@ func (r *valueListResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	valueListItemsStrings, valueListItemsNumbers, diags := RestructureValueList(ctx, valueList.ItemType, valueList.ValueListItems)
+	items := valueList.ValueListItems
+	if state.ItemsManagedExternally.ValueBool() {
+		ownedItems, _, diags := valueListItemsFromSets(ctx, state.ValueListItemsStrings, state.ValueListItemsNumbers)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
+		items = ownedValueListItems(items, ownedItems)
+	}
+
+	valueListItemsStrings, valueListItemsNumbers, diags := valueListItemSets(ctx, valueList.ItemType, items)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -246,6 +370,11 @@ func (r *valueListResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *valueListResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan valueListResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -257,35 +386,85 @@ func (r *valueListResource) Update(ctx context.Context, req resource.UpdateReque
 	if itemType == "error" {
 		resp.Diagnostics.AddError(
 			"Invalid value list items",
-			"Only one of \"value_list_items_strings\" or \"value_list_items_numbers\" can be set",
+			"Only one of \"value_list_items_strings\" or \"value_list_items_numbers\" can be set, and it must match \"item_type\" when that is set",
 		)
 		return
 	}
 
-	var valueListToUpdate = authsignal.ValueList{
-		IsActive: authsignal.SetValue(plan.IsActive.ValueBool()),
+	var state valueListResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	valueListItems, diags := prepareValueListItems(ctx, plan, itemType, true)
+	currentItems, _, diags := valueListItemsFromSets(ctx, state.ValueListItemsStrings, state.ValueListItemsNumbers)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	valueListToUpdate.ValueListItems = valueListItems
+	plannedItems, _, diags := valueListItemsFromSets(ctx, plan.ValueListItemsStrings, plan.ValueListItemsNumbers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	valueList, _, err := r.client.UpdateValueList(plan.Alias.ValueString(), valueListToUpdate)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating value list",
-			"Could not create value list, unexpected error: "+err.Error(),
-		)
+	client, diags := r.client.forTenant(plan.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	plan.Alias = types.StringValue(valueList.Alias)
-	plan.IsActive = types.BoolValue(valueList.IsActive)
-	plan.ItemType = types.StringValue(valueList.ItemType)
+	if !plan.IsActive.Equal(state.IsActive) {
+		var valueListToUpdate = authsignal.ValueList{
+			IsActive: authsignal.SetValue(plan.IsActive.ValueBool()),
+		}
+
+		valueList, _, err := client.UpdateValueList(ctx, plan.Alias.ValueString(), valueListToUpdate)
+		if err != nil {
+			resp.Diagnostics.Append(apiErrorDiagnostics(
+				"Error updating value list",
+				"Could not update value list",
+				err,
+				resourceApiErrorFields(ctx, r, nil),
+			)...)
+			return
+		}
+
+		plan.Alias = types.StringValue(valueList.Alias)
+		plan.IsActive = types.BoolValue(valueList.IsActive)
+		plan.ItemType = types.StringValue(valueList.ItemType)
+	}
+
+	// The API only replaces a value list's items as a whole, so they are sent whenever any differ.
+	added, removed := diffValueListItems(currentItems, plannedItems)
+
+	// Until now the value list owned every item, so handing them over to be managed elsewhere removes none.
+	if plan.ItemsManagedExternally.ValueBool() && !state.ItemsManagedExternally.ValueBool() {
+		removed = nil
+	}
+
+	if len(added) > 0 || len(removed) > 0 {
+		var err error
+		if plan.ItemsManagedExternally.ValueBool() {
+			_, _, err = client.ChangeValueListItems(ctx, plan.Alias.ValueString(), func(*authsignal.ValueListResponse) ([]authsignal.ValueListItem, []authsignal.ValueListItem) {
+				return added, removed
+			})
+		} else {
+			_, _, err = client.SetValueListItems(ctx, plan.Alias.ValueString(), plannedItems)
+		}
+
+		if err != nil {
+			resp.Diagnostics.Append(apiErrorDiagnostics(
+				"Error updating value list",
+				"Could not update the value list's items",
+				err,
+				resourceApiErrorFields(ctx, r, nil),
+			)...)
+			return
+		}
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -295,6 +474,11 @@ func (r *valueListResource) Update(ctx context.Context, req resource.UpdateReque
 }
 
 func (r *valueListResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.client.checkWritable("delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state valueListResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -302,18 +486,27 @@ func (r *valueListResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	_, _, err := r.client.DeleteValueList(state.Alias.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
+	client, diags := r.client.forTenant(state.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, statusCode, err := client.DeleteValueList(ctx, state.Alias.ValueString())
+	if err != nil && statusCode != 404 {
+		resp.Diagnostics.Append(apiErrorDiagnostics(
 			"Error Deleting Authsignal value list",
-			"Could not delete value list, unexpected error: "+err.Error(),
-		)
+			"Could not delete value list",
+			err,
+			resourceApiErrorFields(ctx, r, nil),
+		)...)
 		return
 	}
 }
 
 func (r *valueListResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("alias"), req, resp)
+	id := r.client.importTenant(ctx, req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("alias"), id)...)
 }
 
 func (r *valueListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -321,12 +514,12 @@ func (r *valueListResource) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*authsignal.Client)
+	client, ok := req.ProviderData.(*authsignalClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *authsignal.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
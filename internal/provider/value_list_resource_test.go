@@ -1,9 +1,13 @@
 package provider
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccValueListResource(t *testing.T) {
@@ -24,9 +28,9 @@ func TestAccValueListResource(t *testing.T) {
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "alias", "terraform-acc-test-strings"),
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "item_type", "string"),
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "is_active", "true"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.0", "terraform"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.1", "acceptance"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.2", "test"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.*", "terraform"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.*", "acceptance"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.*", "test"),
 				),
 			},
 			// Create and Read testing for numbers
@@ -43,9 +47,9 @@ func TestAccValueListResource(t *testing.T) {
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "alias", "terraform-acc-test-numbers"),
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "item_type", "number"),
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "is_active", "true"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.0", "1"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.1", "2"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.2", "3"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.*", "1"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.*", "2"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.*", "3"),
 				),
 			},
 			// Update testing for strings without recreate
@@ -62,9 +66,9 @@ func TestAccValueListResource(t *testing.T) {
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "alias", "terraform-acc-test-strings"),
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "item_type", "string"),
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "is_active", "false"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.0", "updated"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.1", "terraform"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.2", "test"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.*", "updated"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.*", "terraform"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.*", "test"),
 				),
 			},
 			// Update testing for numbers without recreate
@@ -81,11 +85,22 @@ func TestAccValueListResource(t *testing.T) {
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "alias", "terraform-acc-test-numbers"),
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "item_type", "number"),
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "is_active", "false"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.0", "4"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.1", "5"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.2", "6"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.*", "4"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.*", "5"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.*", "6"),
 				),
 			},
+			// Reordering the items plans no changes
+			{
+				Config: `
+					resource "authsignal_value_list" "terraform-acc-test-strings" {
+						name = "Terraform Acc Test Strings"
+						is_active = false
+						value_list_items_strings = ["test", "updated", "terraform"]
+					}
+				`,
+				PlanOnly: true,
+			},
 			// Update testing for strings with recreate
 			{
 				Config: `
@@ -100,9 +115,9 @@ func TestAccValueListResource(t *testing.T) {
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "alias", "terraform-acc-test-strings-updated"),
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "item_type", "string"),
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "is_active", "false"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.0", "foo"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.1", "bar"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.2", "baz"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.*", "foo"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.*", "bar"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-strings", "value_list_items_strings.*", "baz"),
 				),
 			},
 			// Update testing for numbers with recreate
@@ -119,11 +134,81 @@ func TestAccValueListResource(t *testing.T) {
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "alias", "terraform-acc-test-numbers-updated"),
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "item_type", "number"),
 					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "is_active", "false"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.0", "7"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.1", "8"),
-					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.2", "9"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.*", "7"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.*", "8"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-numbers", "value_list_items_numbers.*", "9"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccValueListResourceLargeList(t *testing.T) {
+	var writes []string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create testing with thousands of items
+			{
+				Config: `
+					resource "authsignal_value_list" "terraform-acc-test-large" {
+						name = "Terraform Acc Test Large"
+						is_active = true
+						value_list_items_strings = [for i in range(2500) : "user-${i}@example.com"]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-large", "value_list_items_strings.#", "2500"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-large", "value_list_items_strings.*", "user-2499@example.com"),
+					testAccRecordValueListWrites(&writes),
+				),
+			},
+			// Update testing sends the whole list in one request
+			{
+				Config: `
+					resource "authsignal_value_list" "terraform-acc-test-large" {
+						name = "Terraform Acc Test Large"
+						is_active = true
+						value_list_items_strings = [for i in range(1, 2501) : "user-${i}@example.com"]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("authsignal_value_list.terraform-acc-test-large", "value_list_items_strings.#", "2500"),
+					resource.TestCheckTypeSetElemAttr("authsignal_value_list.terraform-acc-test-large", "value_list_items_strings.*", "user-2500@example.com"),
+					func(s *terraform.State) error {
+						created := writes
+						if err := testAccRecordValueListWrites(&writes)(s); err != nil || testAccFakeApi == nil {
+							return err
+						}
+
+						updated := writes[len(created):]
+						expected := []string{"PATCH /value-lists/terraform-acc-test-large"}
+						if strings.Join(updated, ",") != strings.Join(expected, ",") {
+							return fmt.Errorf("bad update requests. expected: %v. got : %v", expected, updated)
+						}
+						return nil
+					},
 				),
 			},
 		},
 	})
 }
+
+// testAccRecordValueListWrites records the requests that change value lists the fake Management API
+// has received.
+func testAccRecordValueListWrites(requests *[]string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if testAccFakeApi == nil {
+			return nil
+		}
+
+		*requests = nil
+		for _, request := range testAccFakeApi.sent() {
+			if strings.Contains(request, " /value-lists") && !strings.HasPrefix(request, http.MethodGet+" ") {
+				*requests = append(*requests, request)
+			}
+		}
+		return nil
+	}
+}
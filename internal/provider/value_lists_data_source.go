@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &valueListsDataSource{}
+	_ datasource.DataSourceWithConfigure = &valueListsDataSource{}
+)
+
+func NewValueListsDataSource() datasource.DataSource {
+	return &valueListsDataSource{}
+}
+
+type valueListsDataSource struct {
+	client *authsignalClient
+}
+
+type valueListsDataSourceModel struct {
+	ValueLists []valueListDataSourceModel `tfsdk:"value_lists"`
+	Tenant     types.String               `tfsdk:"tenant"`
+}
+
+func (d *valueListsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_value_lists"
+}
+
+func (d *valueListsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the value lists on a tenant. Reading it fails until the Management API client can list them.",
+		Attributes: map[string]schema.Attribute{
+			"tenant": tenantDataSourceAttribute(),
+			"value_lists": schema.ListNestedAttribute{
+				Description: "The tenant's value lists, ordered by alias.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tenant": tenantListedAttribute(),
+						"name": schema.StringAttribute{
+							Description: "The name of the list.",
+							Computed:    true,
+						},
+						"alias": schema.StringAttribute{
+							Description: "The hypenated name of the list.",
+							Computed:    true,
+						},
+						"item_type": schema.StringAttribute{
+							Description: "The type of the items in the list. Allowed values: `string`, `number`.",
+							Computed:    true,
+						},
+						"value_list_items_strings": schema.ListAttribute{
+							ElementType: types.StringType,
+							Description: "The list of items.",
+							Computed:    true,
+						},
+						"value_list_items_numbers": schema.ListAttribute{
+							ElementType: types.Float64Type,
+							Description: "The list of items.",
+							Computed:    true,
+						},
+						"is_active": schema.BoolAttribute{
+							Description: "Whether or not the list is active. This currently has no effect.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *valueListsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data valueListsDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.client.forTenant(data.Tenant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	valueLists, _, err := client.ListValueLists(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to List Authsignal ValueLists", err.Error())
+		return
+	}
+
+	sort.SliceStable(valueLists, func(a, b int) bool {
+		return valueLists[a].Alias < valueLists[b].Alias
+	})
+
+	data.ValueLists = []valueListDataSourceModel{}
+
+	for _, valueList := range valueLists {
+		valueListItemsStrings, valueListItemsNumbers, diags := RestructureValueList(ctx, valueList.ItemType, valueList.ValueListItems)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.ValueLists = append(data.ValueLists, valueListDataSourceModel{
+			Name:                  types.StringValue(valueList.Name),
+			Alias:                 types.StringValue(valueList.Alias),
+			ItemType:              types.StringValue(valueList.ItemType),
+			IsActive:              types.BoolValue(valueList.IsActive),
+			ValueListItemsStrings: valueListItemsStrings,
+			ValueListItemsNumbers: valueListItemsNumbers,
+			Tenant:                data.Tenant,
+		})
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *valueListsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring Authsignal client")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*authsignalClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *authsignalClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
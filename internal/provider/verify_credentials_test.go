@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestCredentialsDiagnostics(t *testing.T) {
+	client := newAuthsignalClient("https://api.authsignal.com/v1/management", "production", "secret")
+	tenants := path.Root("tenants").AtMapKey("staging")
+
+	testCases := []struct {
+		statusCode int
+		attributes path.Path
+		summary    string
+		attribute  path.Path
+	}{
+		{statusCode: 0, attributes: path.Empty(), summary: "Unable to Reach Authsignal API", attribute: path.Root("host")},
+		{statusCode: http.StatusUnauthorized, attributes: path.Empty(), summary: "Invalid Authsignal API Secret", attribute: path.Root("api_secret")},
+		{statusCode: http.StatusForbidden, attributes: path.Empty(), summary: "Wrong Authsignal Tenant", attribute: path.Root("tenant_id")},
+		{statusCode: http.StatusNotFound, attributes: tenants, summary: "Wrong Authsignal Tenant", attribute: tenants.AtName("tenant_id")},
+		{statusCode: http.StatusInternalServerError, attributes: tenants, summary: "Unable to Verify Authsignal Credentials", attribute: tenants.AtName("api_secret")},
+	}
+
+	for _, testCase := range testCases {
+		diags := client.credentialsDiagnostics(testCase.attributes, testCase.statusCode, errors.New("request failed"))
+		if len(diags) != 1 {
+			t.Fatalf("expected a single diagnostic for %d. got : %v", testCase.statusCode, diags)
+		}
+
+		if diags[0].Summary() != testCase.summary {
+			t.Fatalf("bad summary for %d. expected: %q, got : %q", testCase.statusCode, testCase.summary, diags[0].Summary())
+		}
+
+		withPath, ok := diags[0].(interface{ Path() path.Path })
+		if !ok || !withPath.Path().Equal(testCase.attribute) {
+			t.Fatalf("bad attribute for %d. expected: %v, got : %v", testCase.statusCode, testCase.attribute, diags[0])
+		}
+	}
+}
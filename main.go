@@ -5,7 +5,9 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
@@ -27,6 +29,11 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		export(os.Args[2:])
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -43,3 +50,30 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// export writes Terraform configuration, with import blocks, for everything on the tenant that the
+// provider's credentials point at. It is configured with the same AUTHSIGNAL_* environment variables
+// as the provider.
+func export(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s export [--out dir]\n\n", os.Args[0])
+		fmt.Fprintln(flags.Output(), "Writes Terraform configuration and import blocks for every resource on a tenant.")
+		fmt.Fprintln(flags.Output(), "The tenant is configured with the same environment variables as the provider, e.g. AUTHSIGNAL_HOST, AUTHSIGNAL_TENANT_ID and AUTHSIGNAL_API_SECRET.")
+		fmt.Fprintln(flags.Output())
+		flags.PrintDefaults()
+	}
+
+	var out string
+	flags.StringVar(&out, "out", "", "directory to write one .tf file per resource type to. Existing files are never overwritten. Defaults to writing to stdout")
+	_ = flags.Parse(args)
+
+	err := provider.Export(context.Background(), provider.ExportOptions{
+		OutDir:  out,
+		Version: version,
+	})
+
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+}